@@ -0,0 +1,77 @@
+// Package ansi provides the terminal control sequences (cursor movement, screen control, etc.), complementary to the
+// colors (SGR) sequences of the colors package.
+//
+// Sequences are returned as strings (or appended to the byte slices by the Append* functions), and can be written
+// using the Writer, that suppresses them when the output is not a terminal:
+//
+//	var w = ansi.NewWriter(os.Stdout)
+//
+//	_, _ = w.Control(ansi.HideCursor, ansi.CursorUp(2), ansi.EraseLine(ansi.EraseAll))
+//	defer w.Control(ansi.ShowCursor)
+package ansi
+
+import (
+	"io"
+	"strconv"
+
+	"gh.tarampamp.am/colors"
+)
+
+const (
+	esc = "\x1b"
+	csi = esc + "[" // Control Sequence Introducer
+)
+
+// Writer is a writer, that writes the control sequences (see Writer.Control) only when they are enabled. The
+// regular text (see Writer.Write) is always written.
+type Writer struct {
+	io.Writer
+
+	// Enabled allows the control sequences writing. NewWriter sets it to true when the writer is a terminal (see
+	// colors.IsTerminal).
+	Enabled bool
+}
+
+// NewWriter creates a new Writer for the writer.
+func NewWriter(w io.Writer) *Writer { return &Writer{Writer: w, Enabled: colors.IsTerminal(w)} }
+
+// Control writes the control sequences (concatenated, using a single write call), if they are enabled. Otherwise,
+// nothing is written.
+func (w *Writer) Control(seq ...string) (int, error) {
+	if !w.Enabled || len(seq) == 0 {
+		return 0, nil
+	}
+
+	if len(seq) == 1 {
+		return io.WriteString(w.Writer, seq[0])
+	}
+
+	var size int
+
+	for _, s := range seq {
+		size += len(s)
+	}
+
+	var buf = make([]byte, 0, size)
+
+	for _, s := range seq {
+		buf = append(buf, s...)
+	}
+
+	return w.Writer.Write(buf)
+}
+
+// appendCSI appends the CSI sequence with the numeric parameters and the final byte.
+func appendCSI(dst []byte, final byte, params ...int) []byte {
+	dst = append(dst, csi...)
+
+	for i, p := range params {
+		if i > 0 {
+			dst = append(dst, ';')
+		}
+
+		dst = strconv.AppendInt(dst, int64(p), 10)
+	}
+
+	return append(dst, final)
+}
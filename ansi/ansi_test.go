@@ -0,0 +1,97 @@
+package ansi_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"testing"
+
+	"gh.tarampamp.am/colors/ansi"
+)
+
+func ExampleWriter() {
+	var w = ansi.NewWriter(os.Stdout) // the control sequences are suppressed, since the output is not a terminal
+
+	_, _ = w.Control(ansi.HideCursor, ansi.CursorUp(1), ansi.EraseLine(ansi.EraseAll))
+	_, _ = fmt.Fprintln(w, "done")
+	_, _ = w.Control(ansi.ShowCursor)
+
+	// output:
+	// done
+}
+
+func TestWriter_Control(t *testing.T) {
+	var (
+		buf bytes.Buffer
+		w   = ansi.NewWriter(&buf)
+	)
+
+	if w.Enabled {
+		t.Fatal("buffer is not a terminal")
+	}
+
+	_, _ = w.Control(ansi.HideCursor)
+	_, _ = w.Write([]byte("a"))
+
+	w.Enabled = true
+
+	if n, err := w.Control(); n != 0 || err != nil {
+		t.Errorf("unexpected result: %d, %v", n, err)
+	}
+
+	_, _ = w.Control(ansi.ShowCursor)
+	_, _ = w.Control(ansi.CursorUp(1), ansi.CursorDown(2))
+
+	if want := "a\x1b[?25h\x1b[1A\x1b[2B"; buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+}
+
+func TestSequences(t *testing.T) {
+	for name, tt := range map[string]struct {
+		give, want string
+	}{
+		"up":                  {ansi.CursorUp(3), "\x1b[3A"},
+		"up zero":             {ansi.CursorUp(0), ""},
+		"down":                {ansi.CursorDown(1), "\x1b[1B"},
+		"down negative":       {ansi.CursorDown(-1), ""},
+		"forward":             {ansi.CursorForward(10), "\x1b[10C"},
+		"back":                {ansi.CursorBack(2), "\x1b[2D"},
+		"next line":           {ansi.CursorNextLine(2), "\x1b[2E"},
+		"prev line":           {ansi.CursorPrevLine(2), "\x1b[2F"},
+		"column":              {ansi.CursorColumn(5), "\x1b[5G"},
+		"column zero":         {ansi.CursorColumn(0), "\x1b[1G"},
+		"position":            {ansi.CursorPosition(3, 7), "\x1b[3;7H"},
+		"position negative":   {ansi.CursorPosition(-1, 0), "\x1b[1;1H"},
+		"erase line":          {ansi.EraseLine(ansi.EraseToEnd), "\x1b[K"},
+		"erase line start":    {ansi.EraseLine(ansi.EraseToStart), "\x1b[1K"},
+		"erase line all":      {ansi.EraseLine(ansi.EraseAll), "\x1b[2K"},
+		"erase line saved":    {ansi.EraseLine(ansi.EraseSaved), "\x1b[2K"},
+		"erase display":       {ansi.EraseDisplay(ansi.EraseToEnd), "\x1b[J"},
+		"erase display all":   {ansi.EraseDisplay(ansi.EraseAll), "\x1b[2J"},
+		"erase display saved": {ansi.EraseDisplay(ansi.EraseSaved), "\x1b[3J"},
+		"scroll up":           {ansi.ScrollUp(2), "\x1b[2S"},
+		"scroll down":         {ansi.ScrollDown(4), "\x1b[4T"},
+		"scroll region":       {ansi.SetScrollRegion(2, 10), "\x1b[2;10r"},
+		"scroll region swap":  {ansi.SetScrollRegion(5, 1), "\x1b[5;5r"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if tt.give != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, tt.give)
+			}
+		})
+	}
+}
+
+func TestAppend(t *testing.T) {
+	var buf = []byte("x")
+
+	buf = ansi.AppendCursorPosition(buf, 1, 2)
+	buf = ansi.AppendEraseLine(buf, ansi.EraseAll)
+	buf = append(buf, ansi.SaveCursor...)
+	buf = ansi.AppendCursorUp(buf, 0)
+
+	if want := "x\x1b[1;2H\x1b[2K\x1b7"; string(buf) != want {
+		t.Errorf("expected %q, got %q", want, buf)
+	}
+}
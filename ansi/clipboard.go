@@ -0,0 +1,123 @@
+package ansi
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Multiplexer is a terminal multiplexer, that requires the "passthrough" wrapping of the sequences, that it does not
+// understand itself (see Multiplexer.Wrap).
+type Multiplexer uint8
+
+const (
+	MultiplexerNone   Multiplexer = iota // No multiplexer
+	MultiplexerTmux                      // tmux (requires the "allow-passthrough" option since tmux 3.3)
+	MultiplexerScreen                    // GNU screen
+)
+
+// DetectMultiplexer detects the terminal multiplexer using the TMUX and TERM environment variables.
+func DetectMultiplexer() Multiplexer {
+	var term = os.Getenv("TERM")
+
+	switch {
+	case os.Getenv("TMUX") != "", strings.HasPrefix(term, "tmux"):
+		return MultiplexerTmux
+	case strings.HasPrefix(term, "screen"):
+		return MultiplexerScreen
+	}
+
+	return MultiplexerNone
+}
+
+// screenChunkSize is the maximal size of the GNU screen passthrough chunk (the screen limits the DCS string length).
+const screenChunkSize = 76
+
+// Wrap wraps the sequence into the multiplexer passthrough (DCS) sequence, so it's sent to the outer terminal.
+func (m Multiplexer) Wrap(seq string) string {
+	switch m {
+	case MultiplexerTmux: // the ESC characters inside are doubled
+		return esc + "Ptmux;" + strings.ReplaceAll(seq, esc, esc+esc) + esc + "\\"
+	case MultiplexerScreen:
+		var buf strings.Builder
+
+		buf.Grow(len(seq) + (len(seq)/screenChunkSize+1)*4) //nolint:mnd
+
+		for len(seq) > 0 {
+			var n = min(len(seq), screenChunkSize)
+
+			buf.WriteString(esc + "P" + seq[:n] + esc + "\\")
+			seq = seq[n:]
+		}
+
+		return buf.String()
+	}
+
+	return seq
+}
+
+// Selection is a clipboard selection. Some terminals support several selections at once (e.g. "pc").
+type Selection string
+
+// Clipboard selections.
+const (
+	SelectionClipboard Selection = "c" // System clipboard
+	SelectionPrimary   Selection = "p" // Primary selection (X11)
+)
+
+// DefaultClipboardMaxSize is the default maximal clipboard payload size. The base64 encoded payload fits into 100 000
+// bytes, the limit of many terminals (e.g. xterm, hterm).
+const DefaultClipboardMaxSize = 74_994
+
+// ErrClipboardTooLarge is returned when the clipboard payload exceeds the size limit.
+var ErrClipboardTooLarge = errors.New("clipboard payload is too large")
+
+// Clipboard copies the data to the terminal clipboard using the OSC 52 sequence. It works over SSH too, since the
+// clipboard of the local machine (where the terminal emulator runs) is used.
+//
+//	var w = ansi.NewWriter(os.Stdout)
+//
+//	if seq, err := ansi.NewClipboard().Copy([]byte("text")); err == nil {
+//		_, _ = w.Control(seq)
+//	}
+type Clipboard struct {
+	Selection   Selection   // Clipboard selection, SelectionClipboard if empty
+	Terminator  Terminator  // Sequence terminator (detected if empty, and always BEL for the GNU screen)
+	Multiplexer Multiplexer // Terminal multiplexer, the sequence is wrapped for
+	MaxSize     int         // Maximal payload size (in bytes, before encoding), DefaultClipboardMaxSize if zero
+}
+
+// NewClipboard creates a new Clipboard with the detected multiplexer (see DetectMultiplexer).
+func NewClipboard() Clipboard { return Clipboard{Multiplexer: DetectMultiplexer()} }
+
+// Copy returns the sequence, that copies the data to the clipboard. ErrClipboardTooLarge is returned if the data
+// size exceeds the limit.
+func (c Clipboard) Copy(data []byte) (string, error) {
+	var limit = c.MaxSize
+
+	if limit <= 0 {
+		limit = DefaultClipboardMaxSize
+	}
+
+	if len(data) > limit {
+		return "", fmt.Errorf("%w: %d bytes (the limit is %d bytes)", ErrClipboardTooLarge, len(data), limit)
+	}
+
+	var selection, term = c.Selection, c.Terminator.orDetected()
+
+	if selection == "" {
+		selection = SelectionClipboard
+	}
+
+	if c.Multiplexer == MultiplexerScreen { // ST would terminate the passthrough sequence
+		term = BEL
+	}
+
+	var buf = make([]byte, 0, len(osc)+len("52;;")+len(selection)+base64.StdEncoding.EncodedLen(len(data))+len(ST))
+
+	buf = appendOSC(buf, term, "52", sanitize(string(selection), false), base64.StdEncoding.EncodeToString(data))
+
+	return c.Multiplexer.Wrap(string(buf)), nil
+}
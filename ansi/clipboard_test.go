@@ -0,0 +1,122 @@
+package ansi_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gh.tarampamp.am/colors/ansi"
+)
+
+func TestDetectMultiplexer(t *testing.T) {
+	for _, tt := range []struct {
+		giveTmux, giveTerm string
+		want               ansi.Multiplexer
+	}{
+		{"", "xterm-256color", ansi.MultiplexerNone},
+		{"/tmp/tmux-1000/default,123,0", "screen-256color", ansi.MultiplexerTmux},
+		{"", "tmux-256color", ansi.MultiplexerTmux},
+		{"", "screen", ansi.MultiplexerScreen},
+		{"", "", ansi.MultiplexerNone},
+	} {
+		t.Setenv("TMUX", tt.giveTmux)
+		t.Setenv("TERM", tt.giveTerm)
+
+		if got := ansi.DetectMultiplexer(); got != tt.want {
+			t.Errorf("TMUX=%q TERM=%q: expected %d, got %d", tt.giveTmux, tt.giveTerm, tt.want, got)
+		}
+	}
+}
+
+func TestClipboard_Copy(t *testing.T) {
+	t.Setenv("TERM", "rxvt-unicode") // the detected terminator is BEL
+	t.Setenv("STY", "")
+
+	for name, tt := range map[string]struct {
+		give ansi.Clipboard
+		want string
+	}{
+		"default":   {ansi.Clipboard{}, "\x1b]52;c;aGVsbG8=\a"},
+		"primary":   {ansi.Clipboard{Selection: ansi.SelectionPrimary, Terminator: ansi.ST}, "\x1b]52;p;aGVsbG8=\x1b\\"},
+		"both":      {ansi.Clipboard{Selection: "pc"}, "\x1b]52;pc;aGVsbG8=\a"},
+		"malformed": {ansi.Clipboard{Selection: "c;\x1b"}, "\x1b]52;c,;aGVsbG8=\a"},
+		"tmux": {
+			ansi.Clipboard{Multiplexer: ansi.MultiplexerTmux, Terminator: ansi.ST},
+			"\x1bPtmux;\x1b\x1b]52;c;aGVsbG8=\x1b\x1b\\\x1b\\",
+		},
+		"screen": {
+			ansi.Clipboard{Multiplexer: ansi.MultiplexerScreen, Terminator: ansi.ST},
+			"\x1bP\x1b]52;c;aGVsbG8=\a\x1b\\",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.give.Copy([]byte("hello"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestClipboard_CopyDetectedTerminator(t *testing.T) {
+	t.Setenv("TERM", "xterm-256color")
+	t.Setenv("STY", "")
+
+	got, err := ansi.Clipboard{}.Copy([]byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "\x1b]52;c;aGVsbG8=\x1b\\"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestClipboard_CopyScreenChunks(t *testing.T) {
+	var c = ansi.Clipboard{Multiplexer: ansi.MultiplexerScreen}
+
+	got, err := c.Copy([]byte(strings.Repeat("x", 300)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var chunks = strings.Split(strings.TrimSuffix(strings.TrimPrefix(got, "\x1bP"), "\x1b\\"), "\x1b\\\x1bP")
+
+	if len(chunks) != 6 { // 8 + 400 + 1 = 409 bytes
+		t.Fatalf("expected 6 chunks, got %d: %q", len(chunks), got)
+	}
+
+	for _, chunk := range chunks[:len(chunks)-1] {
+		if len(chunk) != 76 {
+			t.Errorf("unexpected chunk size %d: %q", len(chunk), chunk)
+		}
+	}
+
+	if !strings.HasPrefix(chunks[0], "\x1b]52;c;eHh4") || !strings.HasSuffix(chunks[5], "\a") {
+		t.Errorf("unexpected chunks: %q", chunks)
+	}
+}
+
+func TestClipboard_CopyTooLarge(t *testing.T) {
+	if _, err := (ansi.Clipboard{}).Copy(make([]byte, ansi.DefaultClipboardMaxSize)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	_, err := (ansi.Clipboard{}).Copy(make([]byte, ansi.DefaultClipboardMaxSize+1))
+	if !errors.Is(err, ansi.ErrClipboardTooLarge) {
+		t.Fatalf("expected ErrClipboardTooLarge, got %v", err)
+	}
+
+	if want := "clipboard payload is too large: 74995 bytes (the limit is 74994 bytes)"; err.Error() != want {
+		t.Errorf("expected %q, got %q", want, err.Error())
+	}
+
+	_, err = (ansi.Clipboard{MaxSize: 3}).Copy([]byte("abcd"))
+	if !errors.Is(err, ansi.ErrClipboardTooLarge) {
+		t.Errorf("expected ErrClipboardTooLarge, got %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package ansi
+
+// Cursor control sequences.
+const (
+	CursorHome    = csi + "H"    // Move the cursor to the top left corner
+	SaveCursor    = esc + "7"    // Save the cursor position and attributes (DECSC)
+	RestoreCursor = esc + "8"    // Restore the saved cursor position and attributes (DECRC)
+	HideCursor    = csi + "?25l" // Hide the cursor (DECTCEM)
+	ShowCursor    = csi + "?25h" // Show the cursor (DECTCEM)
+)
+
+// appendMove appends the cursor movement sequence, or nothing if the distance is not positive.
+func appendMove(dst []byte, n int, final byte) []byte {
+	if n <= 0 {
+		return dst
+	}
+
+	return appendCSI(dst, final, n)
+}
+
+// CursorUp returns the sequence, that moves the cursor up by n lines (CUU). Empty string is returned for n <= 0.
+func CursorUp(n int) string { return string(AppendCursorUp(nil, n)) }
+
+// AppendCursorUp appends the CursorUp sequence to the dst.
+func AppendCursorUp(dst []byte, n int) []byte { return appendMove(dst, n, 'A') }
+
+// CursorDown returns the sequence, that moves the cursor down by n lines (CUD). Empty string is returned for n <= 0.
+func CursorDown(n int) string { return string(AppendCursorDown(nil, n)) }
+
+// AppendCursorDown appends the CursorDown sequence to the dst.
+func AppendCursorDown(dst []byte, n int) []byte { return appendMove(dst, n, 'B') }
+
+// CursorForward returns the sequence, that moves the cursor right by n columns (CUF). Empty string is returned for
+// n <= 0.
+func CursorForward(n int) string { return string(AppendCursorForward(nil, n)) }
+
+// AppendCursorForward appends the CursorForward sequence to the dst.
+func AppendCursorForward(dst []byte, n int) []byte { return appendMove(dst, n, 'C') }
+
+// CursorBack returns the sequence, that moves the cursor left by n columns (CUB). Empty string is returned for
+// n <= 0.
+func CursorBack(n int) string { return string(AppendCursorBack(nil, n)) }
+
+// AppendCursorBack appends the CursorBack sequence to the dst.
+func AppendCursorBack(dst []byte, n int) []byte { return appendMove(dst, n, 'D') }
+
+// CursorNextLine returns the sequence, that moves the cursor to the beginning of the line n lines down (CNL). Empty
+// string is returned for n <= 0.
+func CursorNextLine(n int) string { return string(AppendCursorNextLine(nil, n)) }
+
+// AppendCursorNextLine appends the CursorNextLine sequence to the dst.
+func AppendCursorNextLine(dst []byte, n int) []byte { return appendMove(dst, n, 'E') }
+
+// CursorPrevLine returns the sequence, that moves the cursor to the beginning of the line n lines up (CPL). Empty
+// string is returned for n <= 0.
+func CursorPrevLine(n int) string { return string(AppendCursorPrevLine(nil, n)) }
+
+// AppendCursorPrevLine appends the CursorPrevLine sequence to the dst.
+func AppendCursorPrevLine(dst []byte, n int) []byte { return appendMove(dst, n, 'F') }
+
+// CursorColumn returns the sequence, that moves the cursor to the column (1-based) of the current line (CHA).
+func CursorColumn(col int) string { return string(AppendCursorColumn(nil, col)) }
+
+// AppendCursorColumn appends the CursorColumn sequence to the dst.
+func AppendCursorColumn(dst []byte, col int) []byte { return appendCSI(dst, 'G', max(col, 1)) }
+
+// CursorPosition returns the sequence, that moves the cursor to the row and column (both 1-based) (CUP).
+func CursorPosition(row, col int) string { return string(AppendCursorPosition(nil, row, col)) }
+
+// AppendCursorPosition appends the CursorPosition sequence to the dst.
+func AppendCursorPosition(dst []byte, row, col int) []byte {
+	return appendCSI(dst, 'H', max(row, 1), max(col, 1))
+}
@@ -0,0 +1,177 @@
+package ansi
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Terminator is an OSC (Operating System Command) sequence terminator. BEL is supported by the most terminals
+// (including the older ones, such as rxvt and screen), while ST is the standard one (ECMA-48), preferred by the modern
+// terminals. An empty terminator is detected using the DetectTerminator.
+type Terminator string
+
+// OSC sequence terminators.
+const (
+	BEL Terminator = "\a"       // Bell character
+	ST  Terminator = esc + "\\" // String Terminator
+)
+
+// DetectTerminator detects the OSC sequence terminator, supported by the terminal, using the TERM and STY
+// environment variables. BEL is returned for the GNU screen (ST would terminate its passthrough sequences), the
+// rxvt family, the Linux console and unknown terminals, and ST for the rest (xterm and the modern terminals).
+func DetectTerminator() Terminator {
+	var term = os.Getenv("TERM")
+
+	if term == "" || os.Getenv("STY") != "" {
+		return BEL
+	}
+
+	for _, prefix := range [...]string{"screen", "rxvt", "eterm", "linux", "dumb"} {
+		if strings.HasPrefix(strings.ToLower(term), prefix) {
+			return BEL
+		}
+	}
+
+	return ST
+}
+
+// orDetected returns the terminator, or the detected one (see DetectTerminator) if it's empty.
+func (t Terminator) orDetected() Terminator {
+	if t == "" {
+		return DetectTerminator()
+	}
+
+	return t
+}
+
+const osc = esc + "]" // Operating System Command
+
+// appendOSC appends the OSC sequence with the parameters (separated with semicolons) and the terminator (BEL if empty).
+func appendOSC(dst []byte, t Terminator, params ...string) []byte {
+	dst = append(dst, osc...)
+
+	for i, p := range params {
+		if i > 0 {
+			dst = append(dst, ';')
+		}
+
+		dst = append(dst, p...)
+	}
+
+	if t == "" {
+		t = BEL
+	}
+
+	return append(dst, t...)
+}
+
+// sanitize removes the control characters (including ESC and BEL, that would terminate the sequence early) from the
+// OSC payload, and replaces the parameter separators (semicolons), if the payload is not the last parameter.
+func sanitize(s string, last bool) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsControl(r):
+			return -1
+		case r == ';' && !last:
+			return ','
+		}
+
+		return r
+	}, s)
+}
+
+// Title returns the sequence, that sets the terminal window (and icon/tab) title (OSC 0).
+func Title(title string, t Terminator) string { return string(AppendTitle(nil, title, t)) }
+
+// AppendTitle appends the Title sequence to the dst.
+func AppendTitle(dst []byte, title string, t Terminator) []byte {
+	return appendOSC(dst, t.orDetected(), "0", sanitize(title, true))
+}
+
+// WindowTitle returns the sequence, that sets the terminal window title only (OSC 2).
+func WindowTitle(title string, t Terminator) string { return string(AppendWindowTitle(nil, title, t)) }
+
+// AppendWindowTitle appends the WindowTitle sequence to the dst.
+func AppendWindowTitle(dst []byte, title string, t Terminator) []byte {
+	return appendOSC(dst, t.orDetected(), "2", sanitize(title, true))
+}
+
+// WorkingDirectory returns the sequence, that reports the current working directory to the terminal (OSC 7), so
+// new tabs (windows) can be opened in the same directory. The host is usually the os.Hostname result.
+func WorkingDirectory(host, dir string, t Terminator) string {
+	return string(AppendWorkingDirectory(nil, host, dir, t))
+}
+
+// AppendWorkingDirectory appends the WorkingDirectory sequence to the dst.
+func AppendWorkingDirectory(dst []byte, host, dir string, t Terminator) []byte {
+	var path = filepath.ToSlash(dir)
+
+	if !strings.HasPrefix(path, "/") { // e.g. "C:/Users" on Windows
+		path = "/" + path
+	}
+
+	var u = url.URL{Scheme: "file", Host: sanitize(host, true), Path: sanitize(path, true)}
+
+	return appendOSC(dst, t.orDetected(), "7", u.String())
+}
+
+// Notify returns the sequence, that sends the desktop notification with the title and body (OSC 777, supported by
+// rxvt-unicode, foot, Ghostty, WezTerm and others).
+func Notify(title, body string, t Terminator) string {
+	return string(AppendNotify(nil, title, body, t))
+}
+
+// AppendNotify appends the Notify sequence to the dst.
+func AppendNotify(dst []byte, title, body string, t Terminator) []byte {
+	return appendOSC(dst, t.orDetected(), "777", "notify", sanitize(title, false), sanitize(body, true))
+}
+
+// NotifyMessage returns the sequence, that sends the desktop notification with the message (OSC 9, supported by
+// iTerm2, Windows Terminal, ConEmu, kitty and others).
+func NotifyMessage(message string, t Terminator) string {
+	return string(AppendNotifyMessage(nil, message, t))
+}
+
+// AppendNotifyMessage appends the NotifyMessage sequence to the dst.
+func AppendNotifyMessage(dst []byte, message string, t Terminator) []byte {
+	message = sanitize(message, true)
+
+	// the message, that starts with a number and a semicolon, is treated as a ConEmu command (e.g. "4;" is progress)
+	if i := strings.IndexByte(message, ';'); i > 0 && strings.Trim(message[:i], "0123456789") == "" {
+		message = " " + message
+	}
+
+	return appendOSC(dst, t.orDetected(), "9", message)
+}
+
+// ProgressState is a state of the progress, reported to the terminal (see Progress).
+type ProgressState uint8
+
+const (
+	ProgressHidden        ProgressState = iota // Hide the progress indicator
+	ProgressNormal                             // Normal progress
+	ProgressError                              // Error state (usually red)
+	ProgressIndeterminate                      // Indeterminate progress (the percent is ignored)
+	ProgressPaused                             // Paused or warning state (usually yellow)
+)
+
+// Progress returns the sequence, that reports the progress (0..100 percents) to the terminal, to be shown in the
+// taskbar or the tab (OSC 9;4, supported by Windows Terminal, ConEmu, Ghostty and others).
+func Progress(state ProgressState, percent int, t Terminator) string {
+	return string(AppendProgress(nil, state, percent, t))
+}
+
+// AppendProgress appends the Progress sequence to the dst.
+func AppendProgress(dst []byte, state ProgressState, percent int, t Terminator) []byte {
+	if state > ProgressPaused {
+		state = ProgressNormal
+	}
+
+	var pct = min(max(percent, 0), 100) //nolint:mnd
+
+	return appendOSC(dst, t.orDetected(), "9", "4", strconv.Itoa(int(state)), strconv.Itoa(pct))
+}
@@ -0,0 +1,82 @@
+package ansi_test
+
+import (
+	"testing"
+
+	"gh.tarampamp.am/colors/ansi"
+)
+
+func TestDetectTerminator(t *testing.T) {
+	for _, tt := range []struct {
+		giveTerm, giveSty string
+		want              ansi.Terminator
+	}{
+		{"xterm-256color", "", ansi.ST},
+		{"alacritty", "", ansi.ST},
+		{"tmux-256color", "", ansi.ST},
+		{"xterm-256color", "1234.pts-0.host", ansi.BEL},
+		{"screen.xterm-256color", "", ansi.BEL},
+		{"rxvt-unicode-256color", "", ansi.BEL},
+		{"Eterm", "", ansi.BEL},
+		{"linux", "", ansi.BEL},
+		{"", "", ansi.BEL},
+	} {
+		t.Setenv("TERM", tt.giveTerm)
+		t.Setenv("STY", tt.giveSty)
+
+		if got := ansi.DetectTerminator(); got != tt.want {
+			t.Errorf("TERM=%q STY=%q: expected %q, got %q", tt.giveTerm, tt.giveSty, tt.want, got)
+		}
+	}
+
+	t.Setenv("TERM", "xterm")
+	t.Setenv("STY", "")
+
+	if got := ansi.Title("foo", ""); got != "\x1b]0;foo\x1b\\" {
+		t.Errorf("expected the detected terminator, got %q", got)
+	}
+}
+
+func TestOSC(t *testing.T) {
+	for name, tt := range map[string]struct {
+		give, want string
+	}{
+		"title":           {ansi.Title("foo", ansi.BEL), "\x1b]0;foo\a"},
+		"title st":        {ansi.Title("foo", ansi.ST), "\x1b]0;foo\x1b\\"},
+		"title sanitized": {ansi.Title("a\x1b]0;b\ac\u009c", ansi.ST), "\x1b]0;a]0;bc\x1b\\"},
+		"title semicolon": {ansi.Title("a;b", ansi.BEL), "\x1b]0;a;b\a"},
+		"window title":    {ansi.WindowTitle("привет", ansi.BEL), "\x1b]2;привет\a"},
+		"cwd": {
+			ansi.WorkingDirectory("host", "/home/user/my dir", ansi.ST),
+			"\x1b]7;file://host/home/user/my%20dir\x1b\\",
+		},
+		"cwd windows":  {ansi.WorkingDirectory("pc", "C:/Users", ansi.BEL), "\x1b]7;file://pc/C:/Users\a"},
+		"cwd no host":  {ansi.WorkingDirectory("", "/tmp", ansi.BEL), "\x1b]7;file:///tmp\a"},
+		"notify":       {ansi.Notify("Build", "done; 0 errors", ansi.BEL), "\x1b]777;notify;Build;done; 0 errors\a"},
+		"notify title": {ansi.Notify("a;b", "c", ansi.BEL), "\x1b]777;notify;a,b;c\a"},
+		"message":      {ansi.NotifyMessage("done", ansi.ST), "\x1b]9;done\x1b\\"},
+		"message cmd":  {ansi.NotifyMessage("4;1;50", ansi.BEL), "\x1b]9; 4;1;50\a"},
+		"message num":  {ansi.NotifyMessage("4 files", ansi.BEL), "\x1b]9;4 files\a"},
+		"progress":     {ansi.Progress(ansi.ProgressNormal, 42, ansi.ST), "\x1b]9;4;1;42\x1b\\"},
+		"progress max": {ansi.Progress(ansi.ProgressError, 420, ansi.BEL), "\x1b]9;4;2;100\a"},
+		"progress bad": {ansi.Progress(100, -1, ansi.BEL), "\x1b]9;4;1;0\a"},
+		"progress off": {ansi.Progress(ansi.ProgressHidden, 0, ansi.BEL), "\x1b]9;4;0;0\a"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if tt.give != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, tt.give)
+			}
+		})
+	}
+}
+
+func TestAppendOSC(t *testing.T) {
+	var buf = []byte("x")
+
+	buf = ansi.AppendTitle(buf, "t", ansi.BEL)
+	buf = ansi.AppendProgress(buf, ansi.ProgressIndeterminate, 0, ansi.BEL)
+
+	if want := "x\x1b]0;t\a\x1b]9;4;3;0\a"; string(buf) != want {
+		t.Errorf("expected %q, got %q", want, buf)
+	}
+}
@@ -0,0 +1,483 @@
+package ansi
+
+import (
+	"unicode/utf8"
+
+	"gh.tarampamp.am/colors"
+)
+
+// TokenKind is a kind of the token, produced by the Parser.
+type TokenKind uint8
+
+const (
+	TokenText    TokenKind = iota // Printable text (UTF-8)
+	TokenControl                  // C0 control character (e.g. "\n", "\r", "\t" or BEL), see Token.Final
+	TokenESC                      // Escape sequence (e.g. "\x1b7" or "\x1b(B")
+	TokenCSI                      // Control sequence (e.g. "\x1b[2J"), except the SGR
+	TokenSGR                      // Select Graphic Rendition control sequence (e.g. "\x1b[1;31m")
+	TokenOSC                      // Operating System Command (e.g. "\x1b]0;title\a")
+	TokenDCS                      // Device Control String (e.g. "\x1bP+q544e\x1b\\")
+	TokenString                   // SOS, PM or APC string (e.g. "\x1b_Gf=100;...\x1b\\"), see Token.Final
+)
+
+// String returns the token kind name.
+func (k TokenKind) String() string {
+	switch k {
+	case TokenText:
+		return "text"
+	case TokenControl:
+		return "control"
+	case TokenESC:
+		return "esc"
+	case TokenCSI:
+		return "csi"
+	case TokenSGR:
+		return "sgr"
+	case TokenOSC:
+		return "osc"
+	case TokenDCS:
+		return "dcs"
+	case TokenString:
+		return "string"
+	}
+
+	return "unknown"
+}
+
+// Param is a control sequence parameter.
+type Param struct {
+	Value   int  // Parameter value (0 if missing)
+	Missing bool // The parameter is omitted (e.g. the first one in "\x1b[;5H")
+	Sub     bool // The parameter is a sub-parameter of the previous one (separated with a colon, e.g. "4:3")
+}
+
+// Params is a list of the control sequence parameters.
+type Params []Param
+
+// Get returns the value of the i-th parameter, or the default value if it's missing.
+func (p Params) Get(i, def int) int {
+	if i < 0 || i >= len(p) || p[i].Missing {
+		return def
+	}
+
+	return p[i].Value
+}
+
+// Token is a token, produced by the Parser. The token slices are valid only until the token handler returns (they are
+// reused by the parser to avoid allocations), so copy them if needed.
+type Token struct {
+	Kind          TokenKind
+	Raw           []byte           // Raw token bytes (including the sequence introducer and terminator)
+	Data          []byte           // Text, or the string payload (OSC, DCS, SOS/PM/APC) without the terminator
+	Prefix        byte             // Private parameters prefix ('<', '=', '>' or '?') of the CSI and DCS sequences
+	Params        Params           // CSI and DCS sequences parameters
+	Intermediates []byte           // Intermediate bytes of the ESC, CSI and DCS sequences
+	Final         byte             // Final byte, the control character (TokenControl) or the string introducer
+	Style         colors.TextStyle // Text style, decoded from the SGR sequence (TokenSGR only)
+}
+
+// parserState is a state of the parser state machine.
+type parserState uint8
+
+const (
+	stateGround parserState = iota
+	stateEscape
+	stateEscapeIntermediate
+	stateCSIEntry
+	stateCSIParam
+	stateCSIIntermediate
+	stateCSIIgnore
+	stateOSCString
+	stateDCSEntry
+	stateDCSParam
+	stateDCSIntermediate
+	stateDCSPassthrough
+	stateDCSIgnore
+	stateSOSPMAPCString
+)
+
+const (
+	maxParams     = 32    // parameters over the limit are ignored
+	maxParamValue = 65535 // parameter values are clamped to the limit
+)
+
+// Parser is a streaming ANSI escape sequences parser (tokenizer), based on the DEC/VT500 state machine
+// (<https://vt100.net/emu/dec_ansi_parser>). It accepts the data in chunks (using the Write method), and the
+// sequences (and multibyte characters) may be split across the chunks.
+//
+//	var p = ansi.NewParser(func(t *ansi.Token) {
+//		if t.Kind == ansi.TokenText {
+//			os.Stdout.Write(t.Data) // strip the escape sequences
+//		}
+//	})
+//
+//	_, _ = io.Copy(p, cmdStdout)
+//	p.Flush()
+//
+// Parser is not goroutine-safe.
+type Parser struct {
+	handler func(*Token)
+	state   parserState
+	token   Token
+
+	raw, data, inter, pending []byte
+	params                    []Param
+	codes                     []int // the SGR codes buffer
+	prefix, final             byte
+	ctrl                      [1]byte // the control character token buffer
+
+	param   Param // the current (collected) parameter
+	inParam bool  // any parameter byte is collected
+	strEsc  bool  // ESC is received in the string state (the next byte may complete the ST)
+}
+
+// NewParser creates a new parser, that calls the handler for every token.
+func NewParser(handler func(*Token)) *Parser { return &Parser{handler: handler} }
+
+// Parse parses the data (as a complete stream), calling the handler for every token.
+func Parse(data []byte, handler func(*Token)) {
+	var p = NewParser(handler)
+
+	_, _ = p.Write(data)
+	p.Flush()
+}
+
+// Write parses the data chunk. It never returns an error. Implements the io.Writer interface.
+func (p *Parser) Write(b []byte) (int, error) {
+	var i int
+
+	if len(p.pending) > 0 {
+		i = p.completeRune(b)
+	}
+
+	for i < len(b) {
+		if p.state != stateGround {
+			p.step(b[i])
+			i++
+
+			continue
+		}
+
+		var j = i
+
+		for j < len(b) && b[j] >= 0x20 && b[j] != 0x7f {
+			j++
+		}
+
+		if j > i {
+			p.text(b[i:j], j == len(b))
+			i = j
+
+			continue
+		}
+
+		switch c := b[i]; c {
+		case '\x1b':
+			p.escape()
+		case 0x7f: // ignored
+		default:
+			p.control(c)
+		}
+
+		i++
+	}
+
+	return len(b), nil
+}
+
+// Flush emits the buffered incomplete multibyte character (as a text token) and discards the incomplete escape
+// sequence. It should be called at the end of the stream.
+func (p *Parser) Flush() {
+	if len(p.pending) > 0 {
+		p.emitText(p.pending)
+		p.pending = p.pending[:0]
+	}
+
+	p.state = stateGround
+}
+
+// text emits the text token. If the text is at the end of the chunk, the trailing incomplete multibyte character is
+// buffered until the next chunk.
+func (p *Parser) text(s []byte, atEnd bool) {
+	if atEnd {
+		if n := incompleteTail(s); n > 0 {
+			p.pending = append(p.pending[:0], s[len(s)-n:]...)
+			s = s[:len(s)-n]
+		}
+	}
+
+	if len(s) > 0 {
+		p.emitText(s)
+	}
+}
+
+// incompleteTail returns the length of the incomplete multibyte character at the end of the text.
+func incompleteTail(s []byte) int {
+	for n := 1; n < utf8.UTFMax && n <= len(s); n++ {
+		switch c := s[len(s)-n]; {
+		case c < utf8.RuneSelf:
+			return 0
+		case utf8.RuneStart(c):
+			if utf8.FullRune(s[len(s)-n:]) {
+				return 0
+			}
+
+			return n
+		}
+	}
+
+	return 0
+}
+
+// completeRune completes the buffered multibyte character using the chunk continuation bytes. Returns the number of
+// the consumed bytes.
+func (p *Parser) completeRune(b []byte) (i int) {
+	for i < len(b) && !utf8.FullRune(p.pending) && !utf8.RuneStart(b[i]) {
+		p.pending = append(p.pending, b[i])
+		i++
+	}
+
+	if i < len(b) || utf8.FullRune(p.pending) {
+		p.emitText(p.pending)
+		p.pending = p.pending[:0]
+	}
+
+	return i
+}
+
+// emitText emits the text token.
+func (p *Parser) emitText(s []byte) {
+	p.token = Token{Kind: TokenText, Raw: s, Data: s}
+	p.handler(&p.token)
+}
+
+// control emits the control character token.
+func (p *Parser) control(c byte) {
+	p.ctrl[0] = c
+	p.token = Token{Kind: TokenControl, Raw: p.ctrl[:], Final: c}
+	p.handler(&p.token)
+}
+
+// escape starts a new escape sequence (the previous incomplete one is discarded).
+func (p *Parser) escape() {
+	p.state = stateEscape
+	p.raw = append(p.raw[:0], '\x1b')
+	p.data, p.inter, p.params = p.data[:0], p.inter[:0], p.params[:0]
+	p.prefix, p.final = 0, 0
+	p.param, p.inParam, p.strEsc = Param{Missing: true}, false, false
+}
+
+// step processes the byte in the escape sequence states.
+func (p *Parser) step(c byte) { //nolint:funlen,gocyclo
+	switch p.state {
+	case stateOSCString, stateDCSPassthrough, stateDCSIgnore, stateSOSPMAPCString:
+		p.stringStep(c)
+
+		return
+	}
+
+	switch {
+	case c == 0x18 || c == 0x1a: // CAN and SUB cancel the sequence
+		p.state = stateGround
+
+		return
+	case c == '\x1b':
+		p.escape()
+
+		return
+	case c < 0x20: // C0 controls are executed inside the sequences
+		p.control(c)
+
+		return
+	case c == 0x7f: // ignored
+		return
+	}
+
+	p.raw = append(p.raw, c)
+
+	switch p.state {
+	case stateEscape, stateEscapeIntermediate:
+		switch {
+		case c >= 0x20 && c <= 0x2f:
+			p.inter = append(p.inter, c)
+			p.state = stateEscapeIntermediate
+		case p.state == stateEscape && c == '[':
+			p.state = stateCSIEntry
+		case p.state == stateEscape && c == ']':
+			p.state = stateOSCString
+		case p.state == stateEscape && c == 'P':
+			p.state = stateDCSEntry
+		case p.state == stateEscape && (c == 'X' || c == '^' || c == '_'):
+			p.final, p.state = c, stateSOSPMAPCString
+		case c >= 0x30 && c <= 0x7e:
+			p.final = c
+			p.dispatch(TokenESC)
+		default: // not an escape sequence byte
+			p.state = stateGround
+		}
+
+	case stateCSIEntry, stateCSIParam, stateCSIIntermediate, stateCSIIgnore:
+		p.state = p.paramStep(c, stateCSIParam, stateCSIIntermediate, stateCSIIgnore)
+
+		if p.state == stateGround && p.final != 0 {
+			if p.final == 'm' && p.prefix == 0 && len(p.inter) == 0 {
+				p.dispatch(TokenSGR)
+			} else {
+				p.dispatch(TokenCSI)
+			}
+		}
+
+	case stateDCSEntry, stateDCSParam, stateDCSIntermediate:
+		p.state = p.paramStep(c, stateDCSParam, stateDCSIntermediate, stateDCSIgnore)
+
+		if p.state == stateGround {
+			p.state = stateDCSPassthrough
+		}
+	}
+}
+
+// paramStep processes the parameters, intermediates and final bytes of the CSI and DCS sequences. Returns the next
+// state (ground when the final byte is received, the final byte is set if the sequence is valid).
+func (p *Parser) paramStep(c byte, param, intermediate, ignore parserState) parserState {
+	var state = p.state
+
+	if state == ignore {
+		if c >= 0x40 && c <= 0x7e {
+			return stateGround
+		}
+
+		return ignore
+	}
+
+	switch {
+	case c >= '<' && c <= '?': // private parameters prefix
+		if state != stateCSIEntry && state != stateDCSEntry {
+			return ignore
+		}
+
+		p.prefix = c
+
+		return param
+	case c >= '0' && c <= ';':
+		if state == intermediate {
+			return ignore
+		}
+
+		p.paramByte(c)
+
+		return param
+	case c >= 0x20 && c <= 0x2f:
+		p.inter = append(p.inter, c)
+
+		return intermediate
+	case c >= 0x40 && c <= 0x7e:
+		if p.inParam {
+			p.pushParam(false)
+		}
+
+		p.final = c
+
+		return stateGround
+	}
+
+	return ignore
+}
+
+// paramByte collects the parameter byte (digit or separator).
+func (p *Parser) paramByte(c byte) {
+	p.inParam = true
+
+	switch c {
+	case ';', ':':
+		p.pushParam(c == ':')
+	default:
+		if p.param.Missing {
+			p.param.Value, p.param.Missing = 0, false
+		}
+
+		p.param.Value = min(p.param.Value*10+int(c-'0'), maxParamValue) //nolint:mnd
+	}
+}
+
+// pushParam appends the collected parameter to the list and starts a new one (a sub-parameter, if the colon
+// separator is used).
+func (p *Parser) pushParam(sub bool) {
+	if len(p.params) < maxParams {
+		p.params = append(p.params, p.param)
+	}
+
+	p.param = Param{Missing: true, Sub: sub}
+}
+
+// stringStep processes the byte in the string states (OSC, DCS, SOS/PM/APC).
+func (p *Parser) stringStep(c byte) {
+	if p.strEsc {
+		p.strEsc = false
+
+		if c == '\\' { // ST
+			p.raw = append(p.raw, '\x1b', c)
+			p.dispatchString()
+
+			return
+		}
+
+		// the string is terminated by the ESC, that starts a new sequence
+		p.raw = append(p.raw, '\x1b')
+		p.dispatchString()
+		p.escape()
+		p.step(c)
+
+		return
+	}
+
+	switch {
+	case c == '\x1b':
+		p.strEsc = true
+	case c == 0x18 || c == 0x1a: // CAN and SUB cancel the sequence
+		p.state = stateGround
+	case c == '\a' && p.state == stateOSCString: // BEL terminates the OSC (xterm)
+		p.raw = append(p.raw, c)
+		p.dispatchString()
+	case c < 0x20 || c == 0x7f: // ignored
+	default:
+		p.raw = append(p.raw, c)
+
+		if p.state != stateDCSIgnore {
+			p.data = append(p.data, c)
+		}
+	}
+}
+
+// dispatchString emits the string sequence token (or nothing for the ignored DCS).
+func (p *Parser) dispatchString() {
+	switch p.state {
+	case stateOSCString:
+		p.dispatch(TokenOSC)
+	case stateDCSPassthrough:
+		p.dispatch(TokenDCS)
+	case stateSOSPMAPCString:
+		p.dispatch(TokenString)
+	default:
+		p.state = stateGround
+	}
+}
+
+// dispatch emits the sequence token and returns to the ground state.
+func (p *Parser) dispatch(kind TokenKind) {
+	p.state = stateGround
+	p.token = Token{
+		Kind:          kind,
+		Raw:           p.raw,
+		Data:          p.data,
+		Prefix:        p.prefix,
+		Params:        p.params,
+		Intermediates: p.inter,
+		Final:         p.final,
+	}
+
+	if kind == TokenSGR {
+		p.token.Style, p.codes = decodeSGR(p.params, p.codes)
+	}
+
+	p.handler(&p.token)
+}
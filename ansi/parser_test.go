@@ -0,0 +1,255 @@
+package ansi_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"gh.tarampamp.am/colors"
+	"gh.tarampamp.am/colors/ansi"
+)
+
+func ExampleParser() {
+	var p = ansi.NewParser(func(t *ansi.Token) {
+		switch t.Kind {
+		case ansi.TokenText:
+			fmt.Printf("text %q\n", t.Data)
+		case ansi.TokenSGR:
+			fmt.Printf("sgr %q bold=%t red=%t\n", t.Raw, t.Style.Has(colors.Bold), t.Style.Has(colors.FgRed))
+		default:
+			fmt.Printf("%s %q\n", t.Kind, t.Raw)
+		}
+	})
+
+	// the sequence is split across the chunks
+	_, _ = p.Write([]byte("\x1b[1;3"))
+	_, _ = p.Write([]byte("1mhello\x1b[0m\n"))
+	p.Flush()
+
+	// output:
+	// sgr "\x1b[1;31m" bold=true red=true
+	// text "hello"
+	// sgr "\x1b[0m" bold=false red=false
+	// control "\n"
+}
+
+// describe returns the token description for the tests.
+func describe(t *ansi.Token) string {
+	var b strings.Builder
+
+	b.WriteString(t.Kind.String())
+
+	switch t.Kind {
+	case ansi.TokenText:
+		fmt.Fprintf(&b, " %q", t.Data)
+	case ansi.TokenControl:
+		fmt.Fprintf(&b, " %q", t.Final)
+	default:
+		if t.Prefix != 0 {
+			fmt.Fprintf(&b, " prefix=%c", t.Prefix)
+		}
+
+		if len(t.Params) > 0 {
+			b.WriteString(" params=")
+
+			for i, p := range t.Params {
+				switch {
+				case i == 0:
+				case p.Sub:
+					b.WriteByte(':')
+				default:
+					b.WriteByte(';')
+				}
+
+				if p.Missing {
+					b.WriteByte('_')
+				} else {
+					fmt.Fprint(&b, p.Value)
+				}
+			}
+		}
+
+		if len(t.Intermediates) > 0 {
+			fmt.Fprintf(&b, " inter=%q", t.Intermediates)
+		}
+
+		if t.Final != 0 {
+			fmt.Fprintf(&b, " final=%c", t.Final)
+		}
+
+		if len(t.Data) > 0 {
+			fmt.Fprintf(&b, " data=%q", t.Data)
+		}
+
+		fmt.Fprintf(&b, " raw=%q", t.Raw)
+	}
+
+	return b.String()
+}
+
+// tokenize parses the data, split into the chunks of the given size (0 for the whole data).
+func tokenize(data string, chunk int) []string {
+	var (
+		out []string
+		p   = ansi.NewParser(func(t *ansi.Token) { out = append(out, describe(t)) })
+	)
+
+	if chunk <= 0 {
+		chunk = len(data)
+	}
+
+	for len(data) > 0 {
+		var n = min(chunk, len(data))
+
+		_, _ = p.Write([]byte(data[:n]))
+		data = data[n:]
+	}
+
+	p.Flush()
+
+	// merge the adjacent text tokens (the text may be split across the chunks)
+	var merged = out[:0]
+
+	for _, s := range out {
+		if last := len(merged) - 1; last >= 0 && strings.HasPrefix(s, "text ") &&
+			strings.HasPrefix(merged[last], "text ") {
+			var a, b string
+
+			_, _ = fmt.Sscanf(merged[last], "text %q", &a)
+			_, _ = fmt.Sscanf(s, "text %q", &b)
+			merged[last] = fmt.Sprintf("text %q", a+b)
+
+			continue
+		}
+
+		merged = append(merged, s)
+	}
+
+	return merged
+}
+
+func TestParser(t *testing.T) {
+	for name, tt := range map[string]struct {
+		give string
+		want []string
+	}{
+		"text":       {"hello, мир", []string{`text "hello, мир"`}},
+		"controls":   {"a\r\n\tb\x7f", []string{`text "a"`, `control '\r'`, `control '\n'`, `control '\t'`, `text "b"`}},
+		"sgr":        {"\x1b[1;31m", []string{`sgr params=1;31 final=m raw="\x1b[1;31m"`}},
+		"sgr empty":  {"\x1b[m", []string{`sgr final=m raw="\x1b[m"`}},
+		"sgr subs":   {"\x1b[4:3;38:2::1:2:3m", []string{`sgr params=4:3;38:2:_:1:2:3 final=m raw="\x1b[4:3;38:2::1:2:3m"`}},
+		"csi":        {"\x1b[2J", []string{`csi params=2 final=J raw="\x1b[2J"`}},
+		"csi prefix": {"\x1b[?25l", []string{`csi prefix=? params=25 final=l raw="\x1b[?25l"`}},
+		"csi missing": {
+			"\x1b[;5H", []string{`csi params=_;5 final=H raw="\x1b[;5H"`},
+		},
+		"csi intermediate": {"\x1b[0 q", []string{`csi params=0 inter=" " final=q raw="\x1b[0 q"`}},
+		"csi private sgr":  {"\x1b[>4;2m", []string{`csi prefix=> params=4;2 final=m raw="\x1b[>4;2m"`}},
+		"csi invalid":      {"\x1b[1?2Hx", []string{`text "x"`}},
+		"csi huge param":   {"\x1b[99999999A", []string{`csi params=65535 final=A raw="\x1b[99999999A"`}},
+		"csi with control": {"\x1b[1\n2A", []string{`control '\n'`, `csi params=12 final=A raw="\x1b[12A"`}},
+		"csi cancelled":    {"\x1b[12\x18x", []string{`text "x"`}},
+		"esc":              {"\x1b7\x1b(B", []string{`esc final=7 raw="\x1b7"`, `esc inter="(" final=B raw="\x1b(B"`}},
+		"esc restarted":    {"\x1b[1\x1b[2A", []string{`csi params=2 final=A raw="\x1b[2A"`}},
+		"osc bel":          {"\x1b]0;title\a", []string{`osc data="0;title" raw="\x1b]0;title\a"`}},
+		"osc st": {
+			"\x1b]8;;http://x\x1b\\link", []string{`osc data="8;;http://x" raw="\x1b]8;;http://x\x1b\\"`, `text "link"`},
+		},
+		"osc interrupted": {
+			"\x1b]0;t\x1b[1m", []string{`osc data="0;t" raw="\x1b]0;t\x1b"`, `sgr params=1 final=m raw="\x1b[1m"`},
+		},
+		"osc utf8": {"\x1b]2;привет\a", []string{`osc data="2;привет" raw="\x1b]2;привет\a"`}},
+		"dcs": {
+			"\x1bP1$r0m\x1b\\", []string{`dcs params=1 inter="$" final=r data="0m" raw="\x1bP1$r0m\x1b\\"`},
+		},
+		"dcs ignored": {"\x1bP1:?x\x1b\\y", []string{`text "y"`}},
+		"apc": {
+			"\x1b_Gf=100\x1b\\", []string{`string final=_ data="Gf=100" raw="\x1b_Gf=100\x1b\\"`},
+		},
+		"incomplete": {"a\x1b[1", []string{`text "a"`}},
+		"invalid utf8 tail": {
+			"a\xd0", []string{`text "a\xd0"`},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for _, chunk := range []int{0, 1, 2, 3} {
+				var got = tokenize(tt.give, chunk)
+
+				if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+					t.Errorf("chunk %d:\nexpected %q\n     got %q", chunk, tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestParser_UTF8Chunks(t *testing.T) {
+	var (
+		text []string
+		p    = ansi.NewParser(func(t *ansi.Token) { text = append(text, string(t.Data)) })
+		data = []byte("日本")
+	)
+
+	for _, b := range data {
+		_, _ = p.Write([]byte{b})
+	}
+
+	p.Flush()
+
+	if want := []string{"日", "本"}; fmt.Sprint(text) != fmt.Sprint(want) {
+		t.Errorf("expected %q, got %q", want, text)
+	}
+}
+
+func TestParser_SGRStyle(t *testing.T) {
+	for give, want := range map[string]colors.TextStyle{
+		"\x1b[m":                     colors.Reset,
+		"\x1b[0m":                    colors.Reset,
+		"\x1b[1;91;44m":              colors.Bold | colors.FgRed | colors.FgBright | colors.BgBlue,
+		"\x1b[2;3;4;5m":              colors.Faint | colors.Italic | colors.Underline | colors.Blinking,
+		"\x1b[7;8;9m":                colors.Reverse | colors.Invisible | colors.Strike,
+		"\x1b[31;32m":                colors.FgGreen,
+		"\x1b[39;49m":                colors.FgDefault | colors.BgDefault,
+		"\x1b[97;107m":               colors.FgWhite | colors.FgBright | colors.BgWhite | colors.BgBright,
+		"\x1b[38;5;196;1m":           colors.Bold,
+		"\x1b[38;2;1;2;3;4m":         colors.Underline,
+		"\x1b[48:2::1:2:3;30m":       colors.FgBlack,
+		"\x1b[22;23m":                0,
+		"\x1b[1;0;3m":                colors.Italic,
+		"\x1b[1;22;3m":               colors.Italic,
+		"\x1b[38;5m":                 0,
+		"\x1b[4:3m":                  colors.Underline,
+		"\x1b[6;48:5:1;5;31m":        colors.Blinking | colors.FgRed,
+		"\x1b[100;40m":               colors.BgBlack,
+		"\x1b[94;31m":                colors.FgRed,
+		"\x1b[38;5;1;38;2;1;2;3;36m": colors.FgCyan,
+	} {
+		t.Run(fmt.Sprintf("%q", give), func(t *testing.T) {
+			var got colors.TextStyle
+
+			ansi.Parse([]byte(give), func(t *ansi.Token) { got = t.Style })
+
+			if got != want {
+				t.Errorf("expected %032b, got %032b", want, got)
+			}
+
+			if parsed, _ := colors.ParseStyle(give); parsed != got { // both decoders agree
+				t.Errorf("colors.ParseStyle: expected %032b, got %032b", got, parsed)
+			}
+		})
+	}
+}
+
+func TestParser_Allocs(t *testing.T) {
+	var (
+		data = []byte("\x1b[1;31mhello\x1b[0m\r\n\x1b]0;title\a\x1b[?25l" +
+			"\x1b[38;5;196mhello\x1b[38;2;1;2;3m\x1b[48:2::1:2:3m\x1b[0m") // unsupported codes are skipped
+		p = ansi.NewParser(func(*ansi.Token) {})
+	)
+
+	_, _ = p.Write(data) // warm up the buffers
+
+	if allocs := testing.AllocsPerRun(100, func() { _, _ = p.Write(data) }); allocs > 0 {
+		t.Errorf("expected no allocations, got %v", allocs)
+	}
+}
@@ -0,0 +1,65 @@
+package ansi
+
+// EraseMode is a mode of the erase sequences (see EraseLine and EraseDisplay).
+type EraseMode uint8
+
+const (
+	EraseToEnd   EraseMode = iota // Erase from the cursor to the end of the line (screen)
+	EraseToStart                  // Erase from the beginning of the line (screen) to the cursor
+	EraseAll                      // Erase the whole line (screen)
+	EraseSaved                    // Erase the whole screen and the scrollback buffer (EraseDisplay only)
+)
+
+// Screen control sequences.
+const (
+	EnterAltScreen    = csi + "?1049h" // Switch to the alternate screen buffer (saving the cursor position)
+	ExitAltScreen     = csi + "?1049l" // Switch back to the main screen buffer (restoring the cursor position)
+	ResetScrollRegion = csi + "r"      // Reset the scrolling region to the whole screen
+)
+
+// EraseLine returns the sequence, that erases the line (or its part) without moving the cursor (EL).
+func EraseLine(mode EraseMode) string { return string(AppendEraseLine(nil, mode)) }
+
+// AppendEraseLine appends the EraseLine sequence to the dst.
+func AppendEraseLine(dst []byte, mode EraseMode) []byte {
+	if mode == EraseToEnd {
+		return append(dst, csi+"K"...)
+	}
+
+	return appendCSI(dst, 'K', int(min(mode, EraseAll)))
+}
+
+// EraseDisplay returns the sequence, that erases the screen (or its part) without moving the cursor (ED).
+func EraseDisplay(mode EraseMode) string { return string(AppendEraseDisplay(nil, mode)) }
+
+// AppendEraseDisplay appends the EraseDisplay sequence to the dst.
+func AppendEraseDisplay(dst []byte, mode EraseMode) []byte {
+	if mode == EraseToEnd {
+		return append(dst, csi+"J"...)
+	}
+
+	return appendCSI(dst, 'J', int(min(mode, EraseSaved)))
+}
+
+// ScrollUp returns the sequence, that scrolls the screen (or the scrolling region) up by n lines (SU). Empty string
+// is returned for n <= 0.
+func ScrollUp(n int) string { return string(AppendScrollUp(nil, n)) }
+
+// AppendScrollUp appends the ScrollUp sequence to the dst.
+func AppendScrollUp(dst []byte, n int) []byte { return appendMove(dst, n, 'S') }
+
+// ScrollDown returns the sequence, that scrolls the screen (or the scrolling region) down by n lines (SD). Empty
+// string is returned for n <= 0.
+func ScrollDown(n int) string { return string(AppendScrollDown(nil, n)) }
+
+// AppendScrollDown appends the ScrollDown sequence to the dst.
+func AppendScrollDown(dst []byte, n int) []byte { return appendMove(dst, n, 'T') }
+
+// SetScrollRegion returns the sequence, that sets the scrolling region to the lines from top to bottom (both 1-based,
+// inclusive) (DECSTBM). Use ResetScrollRegion to reset it.
+func SetScrollRegion(top, bottom int) string { return string(AppendSetScrollRegion(nil, top, bottom)) }
+
+// AppendSetScrollRegion appends the SetScrollRegion sequence to the dst.
+func AppendSetScrollRegion(dst []byte, top, bottom int) []byte {
+	return appendCSI(dst, 'r', max(top, 1), max(bottom, top, 1))
+}
@@ -0,0 +1,28 @@
+package ansi
+
+import "gh.tarampamp.am/colors"
+
+// decodeSGR decodes the SGR parameters into the text style (see colors.StyleFromCodesOK). The codes, that can not be
+// represented by the colors.TextStyle (e.g. 256 and RGB colors), are skipped. The codes buffer is reused to avoid
+// allocations.
+func decodeSGR(params Params, codes []int) (colors.TextStyle, []int) {
+	codes = codes[:0]
+
+	for i, p := range params {
+		if p.Sub { // sub-parameters (e.g. "4:3") are skipped, the main code is applied (as colors.ParseStyle does)
+			continue
+		}
+
+		var code = params.Get(i, 0)
+
+		if (code == 38 || code == 48 || code == 58) && i+1 < len(params) && params[i+1].Sub {
+			continue // the extended color with sub-parameters (e.g. "38:2::1:2:3") can not be represented
+		}
+
+		codes = append(codes, code)
+	}
+
+	var style, _ = colors.StyleFromCodesOK(codes...)
+
+	return style, codes
+}
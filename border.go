@@ -0,0 +1,46 @@
+package colors
+
+// Border is a set of characters, used to draw the borders of tables and boxes. Empty characters are not drawn (e.g.
+// the border without the Horizontal character has no horizontal lines).
+type Border struct {
+	Horizontal, Vertical               string
+	TopLeft, TopMid, TopRight          string
+	MidLeft, Cross, MidRight           string
+	BottomLeft, BottomMid, BottomRight string
+}
+
+// Predefined borders.
+var (
+	BorderNone    = Border{}                                                      //nolint:gochecknoglobals
+	BorderASCII   = Border{"-", "|", "+", "+", "+", "+", "+", "+", "+", "+", "+"} //nolint:gochecknoglobals
+	BorderLight   = Border{"─", "│", "┌", "┬", "┐", "├", "┼", "┤", "└", "┴", "┘"} //nolint:gochecknoglobals
+	BorderHeavy   = Border{"━", "┃", "┏", "┳", "┓", "┣", "╋", "┫", "┗", "┻", "┛"} //nolint:gochecknoglobals
+	BorderRounded = Border{"─", "│", "╭", "┬", "╮", "├", "┼", "┤", "╰", "┴", "╯"} //nolint:gochecknoglobals
+	BorderDouble  = Border{"═", "║", "╔", "╦", "╗", "╠", "╬", "╣", "╚", "╩", "╝"} //nolint:gochecknoglobals
+)
+
+// line returns the horizontal border line for the columns of the given widths, using the left, middle (columns
+// junction) and right characters. An empty string is returned when the border has no horizontal lines.
+func (b Border) line(widths []int, left, mid, right string) string {
+	if b.Horizontal == "" {
+		return ""
+	}
+
+	var buf = make([]byte, 0, 64) //nolint:mnd
+
+	buf = append(buf, left...)
+
+	for i, w := range widths {
+		if i > 0 {
+			buf = append(buf, mid...)
+		}
+
+		for j := 0; j < w; j++ {
+			buf = append(buf, b.Horizontal...)
+		}
+	}
+
+	buf = append(buf, right...)
+
+	return string(buf)
+}
@@ -0,0 +1,224 @@
+package colors
+
+import (
+	"strings"
+)
+
+// Box is a framed panel renderer - the (multi-line, styled) content in the border with an optional title.
+//
+//	var b = colors.Box{Title: "Status", Border: colors.BorderRounded, BorderStyle: colors.FgBlue, PaddingX: 1}
+//
+//	fmt.Print(b.Render("api: " + colors.FgGreen.Wrap("running")))
+type Box struct {
+	Title       string    // Title, drawn on the top border (optional)
+	TitleAlign  Align     // Title alignment
+	TitleStyle  TextStyle // Title style
+	Border      Border    // Border characters set (BorderNone by default)
+	BorderStyle TextStyle // Border style
+	Style       TextStyle // Content area style (e.g. BgBlue to fill the background), including the padding
+	PaddingX    int       // Horizontal padding (spaces on the left and right of the content)
+	PaddingY    int       // Vertical padding (empty lines above and below the content)
+	Width       int       // Content area width (excluding the padding), 0 to fit the content
+	MaxWidth    int       // Maximal box width (including the border and padding), the content is wrapped to fit it
+}
+
+// boxUnlimitedWidth is a wrapping width, used when the box width is not limited.
+const boxUnlimitedWidth = 1 << 30
+
+// innerWidth returns the content area width (excluding the padding) and the wrapped content lines.
+func (b *Box) innerWidth(content string) (int, []string) {
+	var (
+		frame = 2 * b.PaddingX
+		limit = boxUnlimitedWidth
+	)
+
+	if b.Border.Vertical != "" {
+		frame += 2 //nolint:mnd
+	}
+
+	switch {
+	case b.Width > 0:
+		limit = b.Width
+	case b.MaxWidth > 0:
+		limit = max(b.MaxWidth-frame, 1)
+	}
+
+	var lines = WordWrap(content, limit)
+
+	if b.Width > 0 {
+		return b.Width, lines
+	}
+
+	var width = VisibleWidth(b.Title) + 2 //nolint:mnd // the title is surrounded by spaces
+
+	if b.Title == "" {
+		width = 0
+	}
+
+	for _, line := range lines {
+		width = max(width, VisibleWidth(line))
+	}
+
+	return min(width, limit), lines
+}
+
+// Render renders the box with the content. Every line ends with a line break. The styles are not applied when colors
+// are disabled.
+func (b *Box) Render(content string) string { return b.RenderIf(Enabled(), content) }
+
+// RenderIf is the same as Render, but uses the provided colors state instead of the global one.
+func (b *Box) RenderIf(enabled bool, content string) string {
+	var (
+		width, lines = b.innerWidth(content)
+		outer        = width + 2*b.PaddingX
+		buf          strings.Builder
+		padX         = strings.Repeat(" ", b.PaddingX)
+		vertical     = b.BorderStyle.WrapIf(enabled, b.Border.Vertical)
+	)
+
+	b.writeTop(&buf, outer, enabled)
+
+	var writeLine = func(line string) {
+		if b.Border.Vertical != "" {
+			buf.WriteString(vertical)
+		}
+
+		buf.WriteString(b.Style.WrapIf(enabled, padX+keepStyle(AlignLeft.pad(line, width), b.Style, enabled)+padX))
+
+		if b.Border.Vertical != "" {
+			buf.WriteString(vertical)
+		}
+
+		buf.WriteByte('\n')
+	}
+
+	for i := 0; i < b.PaddingY; i++ {
+		writeLine("")
+	}
+
+	for _, line := range lines {
+		writeLine(line)
+	}
+
+	for i := 0; i < b.PaddingY; i++ {
+		writeLine("")
+	}
+
+	if bottom := b.Border.line([]int{outer}, b.Border.BottomLeft, "", b.Border.BottomRight); bottom != "" {
+		buf.WriteString(b.BorderStyle.WrapIf(enabled, bottom))
+		buf.WriteByte('\n')
+	}
+
+	return buf.String()
+}
+
+// writeTop writes the top border line with the title.
+func (b *Box) writeTop(buf *strings.Builder, outer int, enabled bool) {
+	var title string
+
+	if b.Title != "" {
+		title = " " + Truncate(b.Title, max(outer-2, 0), "…") + " " //nolint:mnd
+	}
+
+	if b.Border.Horizontal == "" { // no border - the title is drawn as a separate line
+		if title != "" {
+			if b.Border.Vertical != "" {
+				buf.WriteString(strings.Repeat(" ", VisibleWidth(b.Border.Vertical)))
+			}
+
+			buf.WriteString(b.TitleStyle.WrapIf(enabled, b.TitleAlign.pad(strings.TrimSpace(title), outer)))
+			buf.WriteByte('\n')
+		}
+
+		return
+	}
+
+	if title == "" || VisibleWidth(title) > outer {
+		buf.WriteString(b.BorderStyle.WrapIf(enabled, b.Border.line([]int{outer}, b.Border.TopLeft, "", b.Border.TopRight)))
+		buf.WriteByte('\n')
+
+		return
+	}
+
+	var (
+		rest        = outer - VisibleWidth(title)
+		left, right int
+	)
+
+	switch b.TitleAlign {
+	case AlignLeft:
+		left = min(1, rest)
+	case AlignRight:
+		left = rest - min(1, rest)
+	case AlignCenter:
+		left = rest / 2 //nolint:mnd
+	}
+
+	right = rest - left
+
+	buf.WriteString(b.BorderStyle.WrapIf(enabled, b.Border.TopLeft+strings.Repeat(b.Border.Horizontal, left)))
+	buf.WriteString(b.TitleStyle.WrapIf(enabled, title))
+	buf.WriteString(b.BorderStyle.WrapIf(enabled, strings.Repeat(b.Border.Horizontal, right)+b.Border.TopRight))
+	buf.WriteByte('\n')
+}
+
+// keepStyle re-applies the style after every SGR sequence in the string, that resets it (fully, or any of the style
+// components), so the style (e.g. the background fill) stays intact around the nested styled text.
+func keepStyle(s string, style TextStyle, enabled bool) string {
+	if style == 0 || !enabled || strings.IndexByte(s, '\x1b') < 0 {
+		return s
+	}
+
+	var (
+		_, rawReset = style.rawColorCodes()
+		start       = style.StartIf(enabled)
+		buf         strings.Builder
+	)
+
+	buf.Grow(len(s) + len(start))
+
+	for len(s) > 0 {
+		var n = escapeLen(s)
+
+		if n == 0 {
+			var i = strings.IndexByte(s, '\x1b')
+			if i < 0 {
+				i = len(s)
+			}
+
+			buf.WriteString(s[:i])
+			s = s[i:]
+
+			continue
+		}
+
+		var seq = s[:n]
+
+		buf.WriteString(seq)
+		s = s[n:]
+
+		if isSGR(seq) && sgrResets(seq, rawReset) {
+			buf.WriteString(start)
+		}
+	}
+
+	return buf.String()
+}
+
+// sgrResets returns true if the SGR sequence contains the full reset code (0, or empty parameters), or any of the
+// provided reset codes.
+func sgrResets(seq string, resetCodes []byte) bool {
+	for _, param := range strings.Split(seq[2:len(seq)-1], ";") {
+		if param == "" || param == "0" {
+			return true
+		}
+
+		for _, code := range resetCodes {
+			if param == TextStyle(0).byteToString(code) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
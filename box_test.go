@@ -0,0 +1,149 @@
+package colors_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"gh.tarampamp.am/colors"
+)
+
+func ExampleBox() {
+	colors.Enabled(false) // change to true to see colors
+
+	var b = colors.Box{
+		Title:       "Status",
+		TitleStyle:  colors.Bold,
+		Border:      colors.BorderRounded,
+		BorderStyle: colors.FgBlue,
+		PaddingX:    1,
+	}
+
+	fmt.Print(b.Render("api: " + colors.FgGreen.Wrap("running") + "\ndb:  " + colors.FgRed.Wrap("stopped")))
+
+	// output:
+	// ╭─ Status ─────╮
+	// │ api: running │
+	// │ db:  stopped │
+	// ╰──────────────╯
+}
+
+func TestBox_Render(t *testing.T) {
+	var colorsState = colors.Enabled()
+
+	defer colors.Enabled(colorsState)
+
+	colors.Enabled(false)
+
+	for name, tt := range map[string]struct {
+		giveBox     colors.Box
+		giveContent string
+		want        string
+	}{
+		"no border": {colors.Box{}, "foo\nbar", "foo\nbar\n"},
+		"no border with title": {
+			colors.Box{Title: "T", TitleAlign: colors.AlignCenter}, "foo", " T \nfoo\n",
+		},
+		"ascii": {colors.Box{Border: colors.BorderASCII}, "foo", "+---+\n|foo|\n+---+\n"},
+		"padding": {
+			colors.Box{Border: colors.BorderASCII, PaddingX: 1, PaddingY: 1}, "a",
+			"+---+\n|   |\n| a |\n|   |\n+---+\n",
+		},
+		"title right": {
+			colors.Box{Title: "T", TitleAlign: colors.AlignRight, Border: colors.BorderLight, Width: 6}, "a",
+			"┌── T ─┐\n│a     │\n└──────┘\n",
+		},
+		"title center": {
+			colors.Box{Title: "T", TitleAlign: colors.AlignCenter, Border: colors.BorderHeavy, Width: 7}, "a",
+			"┏━━ T ━━┓\n┃a      ┃\n┗━━━━━━━┛\n",
+		},
+		"title wider than content": {
+			colors.Box{Title: "Title", Border: colors.BorderDouble}, "a",
+			"╔ Title ╗\n║a      ║\n╚═══════╝\n",
+		},
+		"title truncated": {
+			colors.Box{Title: "Long title", Border: colors.BorderASCII, Width: 5}, "a",
+			"+ Lo… +\n|a    |\n+-----+\n",
+		},
+		"wrapped": {
+			colors.Box{Border: colors.BorderASCII, MaxWidth: 9, PaddingX: 1}, "foo bar baz",
+			"+-----+\n| foo |\n| bar |\n| baz |\n+-----+\n",
+		},
+		"wide characters": {
+			colors.Box{Border: colors.BorderLight}, "日本\nab", "┌────┐\n│日本│\n│ab  │\n└────┘\n",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assertEqualValues(t, tt.want, tt.giveBox.Render(tt.giveContent))
+		})
+	}
+}
+
+func TestBox_RenderStyled(t *testing.T) {
+	var colorsState = colors.Enabled()
+
+	defer colors.Enabled(colorsState)
+
+	colors.Enabled(true)
+
+	var b = colors.Box{
+		Title:       "T",
+		Border:      colors.BorderLight,
+		BorderStyle: colors.FgBlue,
+		Style:       colors.BgBlack,
+		Width:       6,
+	}
+
+	var (
+		out   = b.Render(colors.FgRed.Wrap("foo bar") + " " + colors.BgRed.Wrap("baz"))
+		lines = strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	)
+
+	assertEqualValues(t, 5, len(lines))
+
+	for _, line := range lines {
+		assertEqualValues(t, 8, colors.VisibleWidth(line))
+	}
+
+	// the content style is restored on the next line, and the fill is re-applied after the resets (only)
+	assertEqualValues(t, "\x1b[34m│\x1b[39m\x1b[40m\x1b[31mfoo\x1b[0m\x1b[40m   \x1b[49m\x1b[34m│\x1b[39m", lines[1])
+	assertEqualValues(t, "\x1b[34m│\x1b[39m\x1b[40m\x1b[31mbar\x1b[39m   \x1b[49m\x1b[34m│\x1b[39m", lines[2])
+	assertEqualValues(t, "\x1b[34m│\x1b[39m\x1b[40m\x1b[41mbaz\x1b[49m\x1b[40m   \x1b[49m\x1b[34m│\x1b[39m", lines[3])
+}
+
+func TestBox_RenderStyledNoBorder(t *testing.T) {
+	var colorsState = colors.Enabled()
+
+	defer colors.Enabled(colorsState)
+
+	colors.Enabled(true)
+
+	var b = colors.Box{Border: colors.BorderNone, BorderStyle: colors.FgRed}
+
+	assertEqualValues(t, "hi\n", b.Render("hi")) // no empty border sequences
+}
+
+func TestBox_RenderIf(t *testing.T) {
+	var colorsState = colors.Enabled()
+
+	defer colors.Enabled(colorsState)
+
+	var b = colors.Box{Title: "T", TitleStyle: colors.Bold, Border: colors.BorderASCII, BorderStyle: colors.FgBlue}
+
+	const (
+		plain  = "+ T +\n|a  |\n+---+\n"
+		styled = "\x1b[34m+\x1b[39m\x1b[1m T \x1b[22m\x1b[34m+\x1b[39m\n" +
+			"\x1b[34m|\x1b[39ma  \x1b[34m|\x1b[39m\n" +
+			"\x1b[34m+---+\x1b[39m\n"
+	)
+
+	for _, global := range []bool{true, false} {
+		colors.Enabled(global)
+
+		assertEqualValues(t, styled, b.RenderIf(true, "a"))
+		assertEqualValues(t, plain, b.RenderIf(false, "a"))
+		assertEqualValues(t, styled, b.RenderContext(colors.WithEnabled(context.Background(), true), "a"))
+		assertEqualValues(t, plain, b.RenderContext(colors.WithEnabled(context.Background(), false), "a"))
+	}
+}
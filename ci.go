@@ -0,0 +1,56 @@
+package colors
+
+import "os"
+
+// CI is a continuous integration environment.
+type CI struct {
+	Name    string  // Human-readable name, e.g. "GitHub Actions"
+	Profile Profile // Color profile supported by the CI log viewer (ProfileNone if colors are not supported)
+}
+
+// ColorsSupported returns true if the CI log viewer renders ANSI colors.
+func (ci CI) ColorsSupported() bool { return ci.Profile != ProfileNone }
+
+// knownCIs is a list of known CI environments with the detection functions. The order matters - the first matched
+// environment wins.
+var knownCIs = [...]struct { //nolint:gochecknoglobals // read-only
+	ci     CI
+	detect func() bool
+}{
+	{ // docs: <https://docs.github.com/en/actions/learn-github-actions/variables#default-environment-variables>
+		CI{Name: "GitHub Actions", Profile: ProfileTrueColor},
+		func() bool { return os.Getenv("GITHUB_ACTIONS") == "true" },
+	},
+	{ // docs: <https://docs.gitlab.com/ee/ci/variables/predefined_variables.html>
+		CI{Name: "GitLab CI", Profile: Profile256},
+		func() bool { return os.Getenv("GITLAB_CI") != "" },
+	},
+	{ // docs: <https://buildkite.com/docs/pipelines/environment-variables>
+		CI{Name: "Buildkite", Profile: Profile256},
+		func() bool { return os.Getenv("BUILDKITE") == "true" },
+	},
+	{ // docs: <https://circleci.com/docs/variables/#built-in-environment-variables>
+		CI{Name: "CircleCI", Profile: Profile16},
+		func() bool { return os.Getenv("CIRCLECI") == "true" },
+	},
+	{ // docs: <https://learn.microsoft.com/en-us/azure/devops/pipelines/build/variables>
+		CI{Name: "Azure Pipelines", Profile: Profile16},
+		func() bool { return os.Getenv("TF_BUILD") != "" && os.Getenv("AGENT_NAME") != "" },
+	},
+	{ // the console output is plain text unless the AnsiColor plugin is installed (and enabled for the job)
+		CI{Name: "Jenkins", Profile: ProfileNone},
+		func() bool { return os.Getenv("JENKINS_URL") != "" && os.Getenv("BUILD_ID") != "" },
+	},
+}
+
+// DetectCI returns the CI environment the current process is running in. The second returned value is false when
+// no known CI environment was detected.
+func DetectCI() (CI, bool) {
+	for _, known := range knownCIs {
+		if known.detect() {
+			return known.ci, true
+		}
+	}
+
+	return CI{}, false
+}
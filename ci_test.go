@@ -0,0 +1,61 @@
+package colors_test
+
+import (
+	"testing"
+
+	"gh.tarampamp.am/colors"
+)
+
+func TestDetectCI(t *testing.T) {
+	var knownEnvs = []string{
+		"GITHUB_ACTIONS", "GITLAB_CI", "BUILDKITE", "CIRCLECI", "TF_BUILD", "AGENT_NAME", "JENKINS_URL", "BUILD_ID",
+	}
+
+	for name, tt := range map[string]struct {
+		giveEnv     map[string]string
+		wantOk      bool
+		wantName    string
+		wantProfile colors.Profile
+	}{
+		"none":           {giveEnv: map[string]string{}},
+		"github actions": {map[string]string{"GITHUB_ACTIONS": "true"}, true, "GitHub Actions", colors.ProfileTrueColor},
+		"gitlab ci":      {map[string]string{"GITLAB_CI": "true"}, true, "GitLab CI", colors.Profile256},
+		"buildkite":      {map[string]string{"BUILDKITE": "true"}, true, "Buildkite", colors.Profile256},
+		"circleci":       {map[string]string{"CIRCLECI": "true"}, true, "CircleCI", colors.Profile16},
+		"azure pipelines": {
+			map[string]string{"TF_BUILD": "True", "AGENT_NAME": "x"}, true, "Azure Pipelines", colors.Profile16,
+		},
+		"jenkins": {
+			map[string]string{"JENKINS_URL": "http://ci/", "BUILD_ID": "1"}, true, "Jenkins", colors.ProfileNone,
+		},
+
+		"github actions (false)": {giveEnv: map[string]string{"GITHUB_ACTIONS": "false"}},
+		"azure (no agent)":       {giveEnv: map[string]string{"TF_BUILD": "True"}},
+		"jenkins (no build id)":  {giveEnv: map[string]string{"JENKINS_URL": "http://ci/"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for _, env := range knownEnvs {
+				t.Setenv(env, "")
+			}
+
+			for k, v := range tt.giveEnv {
+				t.Setenv(k, v)
+			}
+
+			ci, ok := colors.DetectCI()
+
+			assertEqualValues(t, tt.wantOk, ok)
+			assertEqualValues(t, tt.wantName, ci.Name)
+			assertEqualValues(t, tt.wantProfile, ci.Profile)
+			assertEqualValues(t, tt.wantProfile != colors.ProfileNone, ci.ColorsSupported())
+		})
+	}
+}
+
+func TestProfile_String(t *testing.T) {
+	assertEqualValues(t, "none", colors.ProfileNone.String())
+	assertEqualValues(t, "16", colors.Profile16.String())
+	assertEqualValues(t, "256", colors.Profile256.String())
+	assertEqualValues(t, "truecolor", colors.ProfileTrueColor.String())
+	assertEqualValues(t, "unknown", colors.Profile(100).String())
+}
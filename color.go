@@ -0,0 +1,127 @@
+package colors
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Color is a 24-bit RGB color.
+type Color struct{ R, G, B uint8 }
+
+// Fg returns the foreground style for the color.
+func (c Color) Fg() ColorStyle { return ColorStyle{Color: c} }
+
+// Bg returns the background style for the color.
+func (c Color) Bg() ColorStyle { return ColorStyle{Color: c, Background: true} }
+
+// ColorStyle is the foreground or background style of an RGB color. Depending on the current color profile (see
+// CurrentProfile), it is rendered using the true-color escape sequence, or mapped down to the nearest base color that
+// TextStyle supports.
+type ColorStyle struct {
+	Color      Color
+	Background bool // The background color style, if true (the foreground otherwise)
+}
+
+// TextStyle returns the nearest base color text style (e.g. FgRed|FgBright), using the current palette (see
+// CurrentPalette).
+func (cs ColorStyle) TextStyle() TextStyle {
+	var palette = CurrentPalette()
+
+	if cs.Background {
+		return palette.NearestBg(cs.Color)
+	}
+
+	return palette.NearestFg(cs.Color)
+}
+
+// ColorCodes returns color codes for the color style, rendered for the provided color profile. Important note: the
+// result of this function working does not depend on the colors enabling state.
+func (cs ColorStyle) ColorCodes(p Profile) (start, reset string) {
+	switch p {
+	case ProfileNone:
+		return
+	case Profile16:
+		return cs.TextStyle().ColorCodes()
+	}
+
+	var buf = make([]byte, 0, len("\x1b[38;2;255;255;255m"))
+
+	if cs.Background {
+		buf, reset = append(buf, "\x1b[48;"...), "\x1b[49m"
+	} else {
+		buf, reset = append(buf, "\x1b[38;"...), "\x1b[39m"
+	}
+
+	if p == Profile256 {
+		buf = append(buf, "5;"...)
+		buf = strconv.AppendUint(buf, uint64(Nearest256(cs.Color)), 10) //nolint:mnd
+		buf = append(buf, 'm')
+
+		return string(buf), reset
+	}
+
+	buf = append(buf, "2;"...)
+	buf = strconv.AppendUint(buf, uint64(cs.Color.R), 10) //nolint:mnd
+	buf = append(buf, ';')
+	buf = strconv.AppendUint(buf, uint64(cs.Color.G), 10) //nolint:mnd
+	buf = append(buf, ';')
+	buf = strconv.AppendUint(buf, uint64(cs.Color.B), 10) //nolint:mnd
+	buf = append(buf, 'm')
+
+	return string(buf), reset
+}
+
+// String returns a string starting the color styling (useful for usage with fmt.Sprintf).
+func (cs ColorStyle) String() string { return cs.Start() }
+
+// Start returns the color style starting code. An empty string will return when colors are disabled.
+func (cs ColorStyle) Start() string { return cs.StartIf(Enabled()) }
+
+// StartIf is the same as Start, but uses the provided colors state instead of the global one.
+func (cs ColorStyle) StartIf(enabled bool) (start string) {
+	if !enabled {
+		return
+	}
+
+	start, _ = cs.ColorCodes(CurrentProfile())
+
+	return
+}
+
+// Reset returns the color style resetting code. An empty string will return when colors are disabled.
+func (cs ColorStyle) Reset() string { return cs.ResetIf(Enabled()) }
+
+// ResetIf is the same as Reset, but uses the provided colors state instead of the global one.
+func (cs ColorStyle) ResetIf(enabled bool) (reset string) {
+	if !enabled {
+		return
+	}
+
+	_, reset = cs.ColorCodes(CurrentProfile())
+
+	return
+}
+
+// Wrap wraps provided string with staring and reset color codes. The provided string will return without any
+// modifications when colors are disabled.
+func (cs ColorStyle) Wrap(s string) string { return cs.WrapIf(Enabled(), s) }
+
+// WrapIf is the same as Wrap, but uses the provided colors state instead of the global one.
+func (cs ColorStyle) WrapIf(enabled bool, s string) string {
+	if !enabled {
+		return s
+	}
+
+	var (
+		start, reset = cs.ColorCodes(CurrentProfile())
+		buf          strings.Builder
+	)
+
+	buf.Grow(len(start) + len(s) + len(reset))
+
+	buf.WriteString(start)
+	buf.WriteString(s)
+	buf.WriteString(reset)
+
+	return buf.String()
+}
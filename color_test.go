@@ -0,0 +1,103 @@
+package colors_test
+
+import (
+	"fmt"
+	"testing"
+
+	"gh.tarampamp.am/colors"
+)
+
+func ExampleColor_Fg() {
+	colors.Enabled(true)
+	colors.CurrentProfile(colors.ProfileTrueColor)
+
+	fmt.Printf("%q\n", colors.Color{R: 255, G: 165}.Fg().Wrap("orange"))
+
+	colors.CurrentProfile(colors.Profile16)
+
+	fmt.Printf("%q\n", colors.Color{R: 255, G: 165}.Fg().Wrap("orange"))
+
+	// output:
+	// "\x1b[38;2;255;165;0morange\x1b[39m"
+	// "\x1b[33morange\x1b[39m"
+}
+
+func TestCurrentProfile(t *testing.T) {
+	var profile = colors.CurrentProfile()
+
+	defer colors.CurrentProfile(profile)
+
+	assertEqualValues(t, colors.Profile256, colors.CurrentProfile(colors.Profile256))
+	assertEqualValues(t, colors.Profile256, colors.CurrentProfile())
+	assertEqualValues(t, colors.ProfileNone, colors.CurrentProfile(colors.ProfileNone))
+	assertEqualValues(t, colors.ProfileNone, colors.CurrentProfile())
+}
+
+func TestColorStyle_TextStyle(t *testing.T) {
+	for name, tt := range map[string]struct {
+		giveColor    colors.Color
+		wantFg, want colors.TextStyle
+	}{
+		"black": {colors.Color{}, colors.FgBlack, colors.BgBlack},
+		"white": {
+			colors.Color{R: 255, G: 255, B: 255}, colors.FgWhite | colors.FgBright, colors.BgWhite | colors.BgBright,
+		},
+		"light gray":   {colors.Color{R: 220, G: 220, B: 220}, colors.FgWhite, colors.BgWhite},
+		"pure red":     {colors.Color{R: 255}, colors.FgRed | colors.FgBright, colors.BgRed | colors.BgBright},
+		"dark red":     {colors.Color{R: 180}, colors.FgRed, colors.BgRed},
+		"dark blue":    {colors.Color{B: 139}, colors.FgBlue, colors.BgBlue},
+		"dark magenta": {colors.Color{R: 139, B: 139}, colors.FgMagenta, colors.BgMagenta},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assertEqualValues(t, tt.wantFg, tt.giveColor.Fg().TextStyle())
+			assertEqualValues(t, tt.want, tt.giveColor.Bg().TextStyle())
+		})
+	}
+}
+
+func TestColorStyle_ColorCodes(t *testing.T) {
+	var c = colors.Color{R: 1, G: 22, B: 255}
+
+	for name, tt := range map[string]struct {
+		giveStyle            colors.ColorStyle
+		giveProfile          colors.Profile
+		wantStart, wantReset string
+	}{
+		"fg truecolor": {c.Fg(), colors.ProfileTrueColor, "\x1b[38;2;1;22;255m", "\x1b[39m"},
+		"bg truecolor": {c.Bg(), colors.ProfileTrueColor, "\x1b[48;2;1;22;255m", "\x1b[49m"},
+		"fg 16":        {c.Fg(), colors.Profile16, "\x1b[34m", "\x1b[39m"},
+		"bg 16":        {c.Bg(), colors.Profile16, "\x1b[44m", "\x1b[49m"},
+		"fg none":      {c.Fg(), colors.ProfileNone, "", ""},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var start, reset = tt.giveStyle.ColorCodes(tt.giveProfile)
+
+			assertEqualValues(t, tt.wantStart, start)
+			assertEqualValues(t, tt.wantReset, reset)
+		})
+	}
+}
+
+func TestColorStyle_Wrap(t *testing.T) {
+	var (
+		colorsState = colors.Enabled()
+		profile     = colors.CurrentProfile()
+		style       = colors.Color{R: 10, G: 20, B: 30}.Bg()
+	)
+
+	defer func() { colors.Enabled(colorsState); colors.CurrentProfile(profile) }()
+
+	colors.Enabled(true)
+	colors.CurrentProfile(colors.ProfileTrueColor)
+
+	assertEqualValues(t, "\x1b[48;2;10;20;30mFOO\x1b[49m", style.Wrap("FOO"))
+	assertEqualValues(t, "\x1b[48;2;10;20;30m", style.Start())
+	assertEqualValues(t, "\x1b[48;2;10;20;30m", style.String())
+	assertEqualValues(t, "\x1b[49m", style.Reset())
+
+	colors.Enabled(false)
+
+	assertEqualValues(t, "FOO", style.Wrap("FOO"))
+	assertEqualValues(t, "", style.Start())
+	assertEqualValues(t, "", style.Reset())
+}
@@ -0,0 +1,317 @@
+// Package colorflag provides the colored help (usage) output for the standard flag package. Flag names, types,
+// default values and descriptions are styled with the configurable theme, and can be grouped. Descriptions are
+// wrapped to the terminal width, measuring the visible text width (so the escape sequences do not break the
+// alignment).
+//
+//	flag.Usage = colorflag.Usage(flag.CommandLine)
+package colorflag
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"gh.tarampamp.am/colors"
+)
+
+const (
+	defaultWidth     = 80 // used when the terminal width is unknown
+	defaultMaxColumn = 32 // maximal flags column width
+	minDescWidth     = 20 // minimal description width, the description is rendered on the next line otherwise
+)
+
+// Theme is a set of the help output styles.
+type Theme struct {
+	Header      colors.TextStyle // Usage header style
+	Group       colors.TextStyle // Group title style
+	Name        colors.TextStyle // Flag name style (including the dash)
+	Type        colors.TextStyle // Flag value type style
+	Default     colors.TextStyle // Default value style
+	Description colors.TextStyle // Flag description style
+}
+
+// DefaultTheme is the default theme.
+var DefaultTheme = Theme{ //nolint:gochecknoglobals
+	Header:  colors.Bold,
+	Group:   colors.Bold | colors.Underline,
+	Name:    colors.FgGreen | colors.Bold,
+	Type:    colors.FgCyan,
+	Default: colors.Faint,
+}
+
+// Group is a named group of flags.
+type Group struct {
+	Title string   // Group title
+	Flags []string // Flag names (without the dash), in the rendering order
+}
+
+// Printer renders the flag set help.
+//
+//	var p = colorflag.Printer{
+//		Theme:  colorflag.DefaultTheme,
+//		Header: "Usage: app [flags] <file>",
+//		Groups: []colorflag.Group{{Title: "Output", Flags: []string{"format", "color"}}},
+//	}
+//
+//	fs.Usage = p.Usage(fs)
+type Printer struct {
+	Theme  Theme   // Styles
+	Header string  // Usage header, "Usage of <flag set name>:" by default
+	Groups []Group // Flag groups (separated by empty lines), the ungrouped flags are rendered before the groups
+	Width  int     // Output width, the terminal width (see colors.TerminalWidth) or 80 columns by default
+
+	// Colors is the colors mode (e.g. set by the `--color` flag). By default (auto), the colors support is detected
+	// for the flag set output, not for the os.Stdout as the global colors state is.
+	Colors colors.ColorMode
+
+	// MaxColumn is the maximal width of the flags column (32 by default). Longer flags are rendered with the
+	// description on the next line.
+	MaxColumn int
+}
+
+// DefaultPrinter is the printer, used by the Usage function.
+var DefaultPrinter = Printer{Theme: DefaultTheme} //nolint:gochecknoglobals
+
+// Usage returns the usage function for the flag set (a drop-in replacement for the flag.FlagSet.Usage), that
+// writes the colored help into the flag set output using the default printer.
+func Usage(fs *flag.FlagSet) func() { return DefaultPrinter.Usage(fs) }
+
+// Usage returns the usage function for the flag set, that writes the help into the flag set output.
+func (p Printer) Usage(fs *flag.FlagSet) func() {
+	return func() { _, _ = io.WriteString(fs.Output(), p.Render(fs)) }
+}
+
+// item is a rendered flag.
+type item struct {
+	flag        string // styled name and type
+	width       int    // visible width of the flag
+	description string // styled description and the default value
+}
+
+// Render returns the flag set help, styled if colors are enabled for the flag set output (see Printer.Colors).
+func (p Printer) Render(fs *flag.FlagSet) string {
+	return p.RenderIf(p.Colors.Enabled(fs.Output()), fs)
+}
+
+// RenderContext is the same as Render, but respects the colors state carried by the context (see
+// colors.WithEnabled).
+func (p Printer) RenderContext(ctx context.Context, fs *flag.FlagSet) string {
+	return p.RenderIf(colors.EnabledContext(ctx), fs)
+}
+
+// RenderIf is the same as Render, but uses the provided colors state instead of the detected one.
+func (p Printer) RenderIf(enabled bool, fs *flag.FlagSet) string {
+	var (
+		buf     strings.Builder
+		header  = p.Header
+		grouped = make(map[string]struct{})
+	)
+
+	if header == "" {
+		if name := fs.Name(); name != "" {
+			header = "Usage of " + name + ":"
+		} else {
+			header = "Usage:"
+		}
+	}
+
+	buf.WriteString(p.Theme.Header.WrapIf(enabled, header))
+	buf.WriteByte('\n')
+
+	for _, g := range p.Groups {
+		for _, name := range g.Flags {
+			grouped[name] = struct{}{}
+		}
+	}
+
+	var ungrouped []*flag.Flag
+
+	fs.VisitAll(func(f *flag.Flag) {
+		if _, ok := grouped[f.Name]; !ok {
+			ungrouped = append(ungrouped, f)
+		}
+	})
+
+	var sections = [][]item{p.items(ungrouped, enabled)}
+
+	for _, g := range p.Groups {
+		var flags []*flag.Flag
+
+		for _, name := range g.Flags {
+			if f := fs.Lookup(name); f != nil {
+				flags = append(flags, f)
+			}
+		}
+
+		sections = append(sections, p.items(flags, enabled))
+	}
+
+	var (
+		column = p.column(sections)
+		width  = p.width(fs.Output())
+	)
+
+	for i, items := range sections {
+		if len(items) == 0 {
+			continue
+		}
+
+		if i > 0 {
+			buf.WriteByte('\n')
+
+			if title := p.Groups[i-1].Title; title != "" {
+				buf.WriteString(p.Theme.Group.WrapIf(enabled, title) + "\n")
+			}
+		}
+
+		for _, it := range items {
+			p.writeItem(&buf, it, column, width)
+		}
+	}
+
+	return buf.String()
+}
+
+// items returns the rendered flags.
+func (p Printer) items(flags []*flag.Flag, enabled bool) []item {
+	var items = make([]item, 0, len(flags))
+
+	for _, f := range flags {
+		var (
+			typeName, usage = flag.UnquoteUsage(f)
+			name            = "-" + f.Name
+			plain           = name
+			styled          = p.Theme.Name.WrapIf(enabled, name)
+		)
+
+		if typeName != "" {
+			plain += " " + typeName
+			styled += " " + p.Theme.Type.WrapIf(enabled, typeName)
+		}
+
+		var description string
+
+		if usage != "" {
+			description = p.Theme.Description.WrapIf(enabled, usage)
+		}
+
+		if !isZeroValue(f) {
+			var def = f.DefValue
+
+			if reflect.TypeOf(f.Value).String() == "*flag.stringValue" { // as the flag package does
+				def = fmt.Sprintf("%q", def)
+			}
+
+			if description != "" {
+				description += " "
+			}
+
+			description += p.Theme.Default.WrapIf(enabled, "(default "+def+")")
+		}
+
+		items = append(items, item{flag: styled, width: colors.VisibleWidth(plain), description: description})
+	}
+
+	return items
+}
+
+// column returns the flags column width (the widest flag, that fits the MaxColumn).
+func (p Printer) column(sections [][]item) (column int) {
+	var maxColumn = p.MaxColumn
+
+	if maxColumn <= 0 {
+		maxColumn = defaultMaxColumn
+	}
+
+	for _, items := range sections {
+		for _, it := range items {
+			if it.width <= maxColumn {
+				column = max(column, it.width)
+			}
+		}
+	}
+
+	return column
+}
+
+// width returns the output width.
+func (p Printer) width(w io.Writer) int {
+	if p.Width > 0 {
+		return p.Width
+	}
+
+	if width := colors.TerminalWidth(w); width > 0 {
+		return width
+	}
+
+	return defaultWidth
+}
+
+// writeItem writes the flag line(s): "  -name type   description", the description is wrapped to the output width
+// and aligned to the description column.
+func (p Printer) writeItem(buf *strings.Builder, it item, column, width int) {
+	const (
+		indent = "  "
+		gap    = "   "
+	)
+
+	var descIndent = len(indent) + column + len(gap)
+
+	buf.WriteString(indent + it.flag)
+
+	if it.description == "" {
+		buf.WriteByte('\n')
+
+		return
+	}
+
+	switch {
+	case width-descIndent < minDescWidth: // too narrow, the description goes below the flag with the small indent
+		descIndent = len(indent) * 2 //nolint:mnd
+
+		buf.WriteString("\n" + strings.Repeat(" ", descIndent))
+	case it.width > column: // the flag is too wide, the description goes on the next line
+		buf.WriteString("\n" + strings.Repeat(" ", descIndent))
+	default:
+		buf.WriteString(strings.Repeat(" ", column-it.width) + gap)
+	}
+
+	for i, line := range colors.WordWrap(it.description, max(width-descIndent, minDescWidth)) {
+		if i > 0 {
+			buf.WriteString(strings.Repeat(" ", descIndent))
+		}
+
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+}
+
+// isZeroValue reports whether the flag default value is the zero value of the flag type (as the flag package does
+// to decide whether to render the default value).
+func isZeroValue(f *flag.Flag) (zero bool) {
+	defer func() {
+		if recover() != nil { // the flag value String method may panic for the zero value
+			zero = f.DefValue == ""
+		}
+	}()
+
+	var (
+		typ   = reflect.TypeOf(f.Value)
+		value reflect.Value
+	)
+
+	if typ.Kind() == reflect.Pointer {
+		value = reflect.New(typ.Elem())
+	} else {
+		value = reflect.Zero(typ)
+	}
+
+	if v, ok := value.Interface().(flag.Value); ok {
+		return f.DefValue == v.String()
+	}
+
+	return f.DefValue == ""
+}
@@ -0,0 +1,189 @@
+package colorflag_test
+
+import (
+	"context"
+	"flag"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"gh.tarampamp.am/colors"
+	"gh.tarampamp.am/colors/colorflag"
+	"gh.tarampamp.am/colors/colorstest"
+)
+
+// newFlagSet returns the flag set for tests.
+func newFlagSet() *flag.FlagSet {
+	var fs = flag.NewFlagSet("app", flag.ContinueOnError)
+
+	fs.String("addr", ":8080", "listen `address`")
+	fs.Bool("verbose", false, "verbose output")
+	fs.Duration("timeout", 5*time.Second, "request timeout")
+	fs.Int("retries", 0, "number of retries, zero to disable the retries at all (useful for debugging)")
+	fs.String("log-format", "", "")
+	fs.Func("tag", "add a tag (can be repeated)", func(string) error { return nil })
+
+	return fs
+}
+
+func ExamplePrinter() {
+	var fs = newFlagSet()
+
+	fs.SetOutput(os.Stdout)
+
+	fs.Usage = colorflag.Printer{
+		Theme:  colorflag.DefaultTheme,
+		Header: "Usage: app [flags]",
+		Groups: []colorflag.Group{{Title: "Logging:", Flags: []string{"verbose", "log-format"}}},
+		Width:  60,
+		Colors: colors.ColorModeNever, // change to colors.ColorModeAlways to see colors
+	}.Usage(fs)
+
+	fs.Usage()
+
+	// output:
+	// Usage: app [flags]
+	//   -addr address        listen address (default ":8080")
+	//   -retries int         number of retries, zero to disable
+	//                        the retries at all (useful for
+	//                        debugging)
+	//   -tag value           add a tag (can be repeated)
+	//   -timeout duration    request timeout (default 5s)
+	//
+	// Logging:
+	//   -verbose             verbose output
+	//   -log-format string
+}
+
+func TestPrinter_Render(t *testing.T) {
+
+	for name, tt := range map[string]struct {
+		give colorflag.Printer
+		want string
+	}{
+		"default header": {
+			give: colorflag.Printer{Width: 80},
+			want: "Usage of app:\n" +
+				"  -addr address        listen address (default \":8080\")\n" +
+				"  -log-format string\n" +
+				"  -retries int         number of retries, zero to disable the retries at all\n" +
+				"                       (useful for debugging)\n" +
+				"  -tag value           add a tag (can be repeated)\n" +
+				"  -timeout duration    request timeout (default 5s)\n" +
+				"  -verbose             verbose output\n",
+		},
+		"narrow column": {
+			give: colorflag.Printer{Width: 80, MaxColumn: 13, Groups: []colorflag.Group{
+				{Title: "Network:", Flags: []string{"addr", "timeout", "unknown"}},
+				{Title: "Empty:", Flags: []string{"unknown"}},
+			}},
+			want: "Usage of app:\n" +
+				"  -log-format string\n" +
+				"  -retries int    number of retries, zero to disable the retries at all (useful\n" +
+				"                  for debugging)\n" +
+				"  -tag value      add a tag (can be repeated)\n" +
+				"  -verbose        verbose output\n" +
+				"\n" +
+				"Network:\n" +
+				"  -addr address   listen address (default \":8080\")\n" +
+				"  -timeout duration\n" +
+				"                  request timeout (default 5s)\n",
+		},
+		"too narrow": {
+			give: colorflag.Printer{Width: 30, Groups: []colorflag.Group{{Flags: []string{"retries"}}}},
+			want: "Usage of app:\n" +
+				"  -addr address\n" +
+				"    listen address (default\n" +
+				"    \":8080\")\n" +
+				"  -log-format string\n" +
+				"  -tag value\n" +
+				"    add a tag (can be\n" +
+				"    repeated)\n" +
+				"  -timeout duration\n" +
+				"    request timeout (default\n" +
+				"    5s)\n" +
+				"  -verbose\n" +
+				"    verbose output\n" +
+				"\n" +
+				"  -retries int\n" +
+				"    number of retries, zero to\n" +
+				"    disable the retries at all\n" +
+				"    (useful for debugging)\n",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.give.RenderIf(false, newFlagSet()); got != tt.want {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPrinter_Render_Styled(t *testing.T) {
+	colorstest.Force(t, false) // the global colors state does not matter
+
+	var fs = flag.NewFlagSet("", flag.ContinueOnError)
+
+	fs.String("name", "bob", "user `name` to greet the user with the very long description")
+	fs.Bool("force", false, "")
+
+	var printer = colorflag.DefaultPrinter
+
+	printer.Width = 60
+	printer.Colors = colors.ColorModeAlways
+	printer.Theme.Description = colors.Italic
+
+	const want = "[bold]Usage:[/]\n" +
+		"  [bold,green]-force[/]\n" +
+		"  [bold,green]-name[/] [cyan]name[/]   [italic]user name to greet the user with the very[/]\n" +
+		"               [italic]long description[/] [faint](default \"bob\")[/]\n"
+
+	if got := colorstest.Markup(printer.Render(fs)); got != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	colorstest.Force(t, true) // the global colors state does not matter, the output is not a terminal
+	t.Setenv("FORCE_COLOR", "")
+	_ = os.Unsetenv("FORCE_COLOR") // restored by the t.Setenv cleanup
+	t.Setenv("COLUMNS", "100")
+
+	var (
+		fs  = newFlagSet()
+		buf strings.Builder
+	)
+
+	fs.SetOutput(&buf)
+	fs.Usage = colorflag.Usage(fs)
+
+	if err := fs.Parse([]string{"-unknown"}); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	const want = "  -retries int         number of retries, zero to disable the retries at all (useful for debugging)\n"
+
+	if got := buf.String(); !strings.Contains(got, want) {
+		t.Errorf("expected the output to contain %q, got:\n%s", want, got)
+	}
+}
+
+func TestPrinter_RenderContext(t *testing.T) {
+	colorstest.Force(t, false)
+
+	var (
+		fs      = flag.NewFlagSet("", flag.ContinueOnError)
+		printer = colorflag.Printer{Theme: colorflag.Theme{Name: colors.Bold}}
+	)
+
+	fs.Bool("force", false, "")
+
+	for enabled, want := range map[bool]string{true: "Usage:\n  [bold]-force[/]\n", false: "Usage:\n  -force\n"} {
+		var ctx = colors.WithEnabled(context.Background(), enabled)
+
+		if got := colorstest.Markup(printer.RenderContext(ctx, fs)); got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package colors
 
 import (
+	"io"
 	"os"
 	"strings"
 	"sync"
@@ -18,17 +19,43 @@ var colorsEnabled = initColorsState() //nolint:gochecknoglobals // atomic usage
 
 // initColorsState returns initialization value for the colors enabled state.
 func initColorsState() uint32 {
-	if _, exists := os.LookupEnv("FORCE_COLOR"); exists {
+	if detectColors(os.Stdout) {
 		return colorsOn
+	}
+
+	return colorsOff
+}
+
+// detectColors returns true if colors should be enabled for the output into the provided writer.
+func detectColors(w io.Writer) bool {
+	if _, exists := os.LookupEnv("FORCE_COLOR"); exists {
+		return true
 	} else if _, exists = os.LookupEnv("NO_COLOR"); exists { // docs: <https://no-color.org/>
-		return colorsOff
+		return false
 	} else if os.Getenv("TERM") == "dumb" {
-		return colorsOff
-	} else if !isatty.IsTerminal(os.Stdout.Fd()) && !isatty.IsCygwinTerminal(os.Stdout.Fd()) {
-		return colorsOff
+		return false
+	}
+
+	if _, isFile := w.(interface{ Fd() uintptr }); !isFile {
+		return false
+	}
+
+	if ci, isCI := DetectCI(); isCI && ci.ColorsSupported() { // CI log viewers render colors without a TTY
+		return true
 	}
 
-	return colorsOn
+	return IsTerminal(w)
+}
+
+// IsTerminal returns true if the writer is a terminal (e.g. os.Stdout, not redirected to a file or pipe). Unlike
+// the colors detection, environment variables are not taken into account.
+func IsTerminal(w io.Writer) bool {
+	f, isFile := w.(interface{ Fd() uintptr })
+	if !isFile {
+		return false
+	}
+
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
 }
 
 // Enabled returns true if colors are enabled. Also, you can set a new state (enable or disable colors).
@@ -107,6 +134,13 @@ const (
 	_ // reserved
 )
 
+// FgMask and BgMask are the masks of all the foreground and background color bits (including the bright and default
+// colors bits), e.g. style&^FgMask removes the foreground color from the style.
+const (
+	FgMask = FgBlack | FgRed | FgGreen | FgYellow | FgBlue | FgMagenta | FgCyan | FgWhite | FgDefault | FgBright
+	BgMask = BgBlack | BgRed | BgGreen | BgYellow | BgBlue | BgMagenta | BgCyan | BgWhite | BgDefault | BgBright
+)
+
 // Has returns true if provided text style included into this one.
 func (ts TextStyle) Has(z TextStyle) bool { return ts&z != 0 }
 
@@ -368,12 +402,15 @@ func (ts TextStyle) ColorCodes() (start, reset string) {
 func (ts TextStyle) String() string { return ts.Start() }
 
 // Start returns current text style starting code. An empty string will return when colors are disabled.
-func (ts TextStyle) Start() (start string) {
+func (ts TextStyle) Start() string { return ts.StartIf(Enabled()) }
+
+// StartIf is the same as Start, but uses the provided colors state instead of the global one.
+func (ts TextStyle) StartIf(enabled bool) (start string) {
 	if ts == 0 {
 		return
 	}
 
-	if !Enabled() {
+	if !enabled {
 		return
 	}
 
@@ -384,12 +421,15 @@ func (ts TextStyle) Start() (start string) {
 
 // Reset returns current text style resetting code. An empty string will return when colors are disabled or when called
 // on FgDefault, BgDefault, or Reset.
-func (ts TextStyle) Reset() (reset string) {
+func (ts TextStyle) Reset() string { return ts.ResetIf(Enabled()) }
+
+// ResetIf is the same as Reset, but uses the provided colors state instead of the global one.
+func (ts TextStyle) ResetIf(enabled bool) (reset string) {
 	if ts == 0 {
 		return
 	}
 
-	if !Enabled() {
+	if !enabled {
 		return
 	}
 
@@ -400,12 +440,16 @@ func (ts TextStyle) Reset() (reset string) {
 
 // Wrap wraps provided string with staring and reset color codes. The provided string will return without any
 // modifications when colors are disabled.
-func (ts TextStyle) Wrap(s string) string {
+func (ts TextStyle) Wrap(s string) string { return ts.WrapIf(Enabled(), s) }
+
+// WrapIf is the same as Wrap, but uses the provided colors state instead of the global one. It is useful when the
+// output should be styled independently of the global state (e.g. for a specific writer, see ColorMode.Enabled).
+func (ts TextStyle) WrapIf(enabled bool, s string) string {
 	if ts == 0 {
 		return s
 	}
 
-	if !Enabled() {
+	if !enabled {
 		return s
 	}
 
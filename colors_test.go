@@ -1,7 +1,10 @@
 package colors_test
 
 import (
+	"bytes"
 	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
 	"sync"
 	"testing"
@@ -56,6 +59,18 @@ func TestColorsEnabled(t *testing.T) {
 	assertTrue(t, colors.Enabled())
 }
 
+func TestIsTerminal(t *testing.T) {
+	assertFalse(t, colors.IsTerminal(&bytes.Buffer{}))
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "out"))
+
+	assertEqualValues(t, nil, err)
+
+	defer func() { _ = f.Close() }()
+
+	assertFalse(t, colors.IsTerminal(f))
+}
+
 func TestTextStyle_Has(t *testing.T) {
 	var s = colors.BgBlack | colors.FgWhite | colors.Bold
 
@@ -70,6 +85,17 @@ func TestTextStyle_Has(t *testing.T) {
 	assertTrue(t, s.Has(colors.Bold))
 }
 
+func TestColorMasks(t *testing.T) {
+	var s = colors.FgRed | colors.FgBright | colors.BgBlue | colors.BgDefault | colors.Bold | colors.Underline
+
+	assertEqualValues(t, colors.FgRed|colors.FgBright, s&colors.FgMask)
+	assertEqualValues(t, colors.BgBlue|colors.BgDefault, s&colors.BgMask)
+	assertEqualValues(t, colors.Bold|colors.Underline, s&^(colors.FgMask|colors.BgMask))
+	assertEqualValues(t, colors.FgGreen|colors.BgBlue|colors.BgDefault|colors.Bold|colors.Underline,
+		s&^colors.FgMask|colors.FgGreen)
+	assertEqualValues(t, colors.TextStyle(0), colors.FgMask&colors.BgMask)
+}
+
 func TestTextStyle_Add(t *testing.T) {
 	var s = colors.BgBlack
 
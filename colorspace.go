@@ -0,0 +1,358 @@
+package colors
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrInvalidHexColor is returned when the hex color string cannot be parsed.
+var ErrInvalidHexColor = errors.New("invalid hex color")
+
+// ParseHex parses the hex color string in the "#rrggbb" or "#rgb" form (the leading "#" is optional,
+// case-insensitive).
+func ParseHex(s string) (Color, error) {
+	if len(s) > 0 && s[0] == '#' {
+		s = s[1:]
+	}
+
+	var digits [6]uint8
+
+	switch len(s) {
+	case 3, 6: //nolint:mnd
+	default:
+		return Color{}, fmt.Errorf("%w: %q", ErrInvalidHexColor, s)
+	}
+
+	for i := 0; i < len(s); i++ {
+		switch ch := s[i]; {
+		case ch >= '0' && ch <= '9':
+			digits[i] = ch - '0'
+		case ch >= 'a' && ch <= 'f':
+			digits[i] = ch - 'a' + 10 //nolint:mnd
+		case ch >= 'A' && ch <= 'F':
+			digits[i] = ch - 'A' + 10 //nolint:mnd
+		default:
+			return Color{}, fmt.Errorf("%w: %q", ErrInvalidHexColor, s)
+		}
+	}
+
+	if len(s) == 3 { //nolint:mnd
+		return Color{digits[0] * 17, digits[1] * 17, digits[2] * 17}, nil //nolint:mnd
+	}
+
+	return Color{digits[0]<<4 | digits[1], digits[2]<<4 | digits[3], digits[4]<<4 | digits[5]}, nil
+}
+
+// Hex returns the color in the "#rrggbb" form.
+func (c Color) Hex() string {
+	const hex = "0123456789abcdef"
+
+	return string([]byte{'#', hex[c.R>>4], hex[c.R&0xf], hex[c.G>>4], hex[c.G&0xf], hex[c.B>>4], hex[c.B&0xf]})
+}
+
+// String returns the color in the "#rrggbb" form.
+func (c Color) String() string { return c.Hex() }
+
+// HSL is a color in the HSL color space. Hue is in degrees (0..360), saturation and lightness are in the 0..1 range.
+type HSL struct{ H, S, L float64 }
+
+// HSV is a color in the HSV color space. Hue is in degrees (0..360), saturation and value are in the 0..1 range.
+type HSV struct{ H, S, V float64 }
+
+// Lab is a color in the CIELAB color space (D65 white point). Lightness is in the 0..100 range.
+type Lab struct{ L, A, B float64 }
+
+// OKLab is a color in the OKLab color space. Lightness is in the 0..1 range.
+// Docs: <https://bottosson.github.io/posts/oklab/>
+type OKLab struct{ L, A, B float64 }
+
+// OKLCH is a color in the OKLCH color space (the polar form of OKLab). Lightness is in the 0..1 range, hue is in
+// degrees (0..360).
+type OKLCH struct{ L, C, H float64 }
+
+// rgbFloats returns the color components in the 0..1 range.
+func (c Color) rgbFloats() (r, g, b float64) {
+	return float64(c.R) / 255, float64(c.G) / 255, float64(c.B) / 255 //nolint:mnd
+}
+
+// colorFromFloats returns the color from the components in the 0..1 range (values out of range are clamped).
+func colorFromFloats(r, g, b float64) Color {
+	var toByte = func(v float64) uint8 { return uint8(math.Round(math.Max(0, math.Min(1, v)) * 255)) } //nolint:mnd
+
+	return Color{toByte(r), toByte(g), toByte(b)}
+}
+
+// srgbToLinear converts the sRGB color component to the linear light value (0..1).
+func srgbToLinear(v uint8) float64 {
+	var f = float64(v) / 255 //nolint:mnd
+
+	if f <= 0.04045 { //nolint:mnd
+		return f / 12.92 //nolint:mnd
+	}
+
+	return math.Pow((f+0.055)/1.055, 2.4) //nolint:mnd
+}
+
+// linearToSRGB converts the linear light value (0..1) to the sRGB color component (0..1).
+func linearToSRGB(v float64) float64 {
+	if v <= 0.0031308 { //nolint:mnd
+		return v * 12.92 //nolint:mnd
+	}
+
+	return 1.055*math.Pow(v, 1/2.4) - 0.055 //nolint:mnd
+}
+
+// normalizeHue wraps the hue into the 0..360 range.
+func normalizeHue(h float64) float64 {
+	if h = math.Mod(h, 360); h < 0 { //nolint:mnd
+		h += 360
+	}
+
+	return h
+}
+
+// hue returns the hue (in degrees) and chroma of the RGB color (components in the 0..1 range).
+func hue(r, g, b float64) (h, maxC, minC float64) {
+	maxC, minC = math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+
+	var d = maxC - minC
+
+	switch {
+	case d == 0:
+		h = 0
+	case maxC == r:
+		h = 60 * math.Mod((g-b)/d, 6) //nolint:mnd
+	case maxC == g:
+		h = 60 * ((b-r)/d + 2) //nolint:mnd
+	default:
+		h = 60 * ((r-g)/d + 4) //nolint:mnd
+	}
+
+	return normalizeHue(h), maxC, minC
+}
+
+// colorFromHueChroma returns the color from the hue (in degrees), chroma and the lightness match value.
+func colorFromHueChroma(h, chroma, m float64) Color {
+	var (
+		hh      = normalizeHue(h) / 60                       //nolint:mnd
+		x       = chroma * (1 - math.Abs(math.Mod(hh, 2)-1)) //nolint:mnd
+		r, g, b float64
+	)
+
+	switch {
+	case hh < 1:
+		r, g, b = chroma, x, 0
+	case hh < 2: //nolint:mnd
+		r, g, b = x, chroma, 0
+	case hh < 3: //nolint:mnd
+		r, g, b = 0, chroma, x
+	case hh < 4: //nolint:mnd
+		r, g, b = 0, x, chroma
+	case hh < 5: //nolint:mnd
+		r, g, b = x, 0, chroma
+	default:
+		r, g, b = chroma, 0, x
+	}
+
+	return colorFromFloats(r+m, g+m, b+m)
+}
+
+// HSL converts the color to the HSL color space.
+func (c Color) HSL() HSL {
+	var (
+		h, maxC, minC = hue(c.rgbFloats())
+		l             = (maxC + minC) / 2 //nolint:mnd
+		s             float64
+	)
+
+	if d := maxC - minC; d != 0 {
+		s = d / (1 - math.Abs(2*l-1)) //nolint:mnd
+	}
+
+	return HSL{H: h, S: s, L: l}
+}
+
+// Color converts the HSL color to RGB.
+func (hsl HSL) Color() Color {
+	var chroma = (1 - math.Abs(2*hsl.L-1)) * hsl.S //nolint:mnd
+
+	return colorFromHueChroma(hsl.H, chroma, hsl.L-chroma/2) //nolint:mnd
+}
+
+// HSV converts the color to the HSV color space.
+func (c Color) HSV() HSV {
+	var (
+		h, maxC, minC = hue(c.rgbFloats())
+		s             float64
+	)
+
+	if maxC != 0 {
+		s = (maxC - minC) / maxC
+	}
+
+	return HSV{H: h, S: s, V: maxC}
+}
+
+// Color converts the HSV color to RGB.
+func (hsv HSV) Color() Color {
+	var chroma = hsv.V * hsv.S
+
+	return colorFromHueChroma(hsv.H, chroma, hsv.V-chroma)
+}
+
+// D65 white point reference values and the CIELAB constants.
+const (
+	labXn, labYn, labZn = 0.95047, 1.0, 1.08883
+	labDelta            = 6.0 / 29
+)
+
+// Lab converts the color to the CIELAB color space.
+func (c Color) Lab() Lab { //nolint:mnd
+	var (
+		r, g, b = srgbToLinear(c.R), srgbToLinear(c.G), srgbToLinear(c.B)
+
+		x = (0.4124564*r + 0.3575761*g + 0.1804375*b) / labXn
+		y = (0.2126729*r + 0.7151522*g + 0.0721750*b) / labYn
+		z = (0.0193339*r + 0.1191920*g + 0.9503041*b) / labZn
+
+		f = func(t float64) float64 {
+			if t > labDelta*labDelta*labDelta {
+				return math.Cbrt(t)
+			}
+
+			return t/(3*labDelta*labDelta) + 4.0/29
+		}
+	)
+
+	return Lab{L: 116*f(y) - 16, A: 500 * (f(x) - f(y)), B: 200 * (f(y) - f(z))}
+}
+
+// Color converts the CIELAB color to RGB.
+func (lab Lab) Color() Color { //nolint:mnd
+	var (
+		fInv = func(t float64) float64 {
+			if t > labDelta {
+				return t * t * t
+			}
+
+			return 3 * labDelta * labDelta * (t - 4.0/29)
+		}
+
+		fy = (lab.L + 16) / 116
+		x  = labXn * fInv(fy+lab.A/500)
+		y  = labYn * fInv(fy)
+		z  = labZn * fInv(fy-lab.B/200)
+	)
+
+	return colorFromFloats(
+		linearToSRGB(3.2404542*x-1.5371385*y-0.4985314*z),
+		linearToSRGB(-0.9692660*x+1.8760108*y+0.0415560*z),
+		linearToSRGB(0.0556434*x-0.2040259*y+1.0572252*z),
+	)
+}
+
+// OKLab converts the color to the OKLab color space.
+func (c Color) OKLab() OKLab { //nolint:mnd
+	var (
+		r, g, b = srgbToLinear(c.R), srgbToLinear(c.G), srgbToLinear(c.B)
+
+		l = math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+		m = math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+		s = math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+	)
+
+	return OKLab{
+		L: 0.2104542553*l + 0.7936177850*m - 0.0040720468*s,
+		A: 1.9779984951*l - 2.4285922050*m + 0.4505937099*s,
+		B: 0.0259040371*l + 0.7827717662*m - 0.8086757660*s,
+	}
+}
+
+// Color converts the OKLab color to RGB.
+func (lab OKLab) Color() Color { //nolint:mnd
+	var (
+		l = lab.L + 0.3963377774*lab.A + 0.2158037573*lab.B
+		m = lab.L - 0.1055613458*lab.A - 0.0638541728*lab.B
+		s = lab.L - 0.0894841775*lab.A - 1.2914855480*lab.B
+	)
+
+	l, m, s = l*l*l, m*m*m, s*s*s
+
+	return colorFromFloats(
+		linearToSRGB(4.0767416621*l-3.3077115913*m+0.2309699292*s),
+		linearToSRGB(-1.2684380046*l+2.6097574011*m-0.3413193965*s),
+		linearToSRGB(-0.0041960863*l-0.7034186147*m+1.7076147010*s),
+	)
+}
+
+// OKLCH converts the OKLab color to the polar OKLCH form.
+func (lab OKLab) OKLCH() OKLCH {
+	return OKLCH{
+		L: lab.L,
+		C: math.Hypot(lab.A, lab.B),
+		H: normalizeHue(math.Atan2(lab.B, lab.A) * 180 / math.Pi), //nolint:mnd
+	}
+}
+
+// OKLab converts the OKLCH color to the OKLab form.
+func (lch OKLCH) OKLab() OKLab {
+	var rad = lch.H * math.Pi / 180 //nolint:mnd
+
+	return OKLab{L: lch.L, A: lch.C * math.Cos(rad), B: lch.C * math.Sin(rad)}
+}
+
+// OKLCH converts the color to the OKLCH color space.
+func (c Color) OKLCH() OKLCH { return c.OKLab().OKLCH() }
+
+// Color converts the OKLCH color to RGB.
+func (lch OKLCH) Color() Color { return lch.OKLab().Color() }
+
+// clamp01 clamps the value into the 0..1 range.
+func clamp01(v float64) float64 { return math.Max(0, math.Min(1, v)) }
+
+// Lighten returns the color with the HSL lightness increased by the amount (0..1, e.g. 0.1 for 10%).
+func (c Color) Lighten(amount float64) Color {
+	var hsl = c.HSL()
+
+	hsl.L = clamp01(hsl.L + amount)
+
+	return hsl.Color()
+}
+
+// Darken returns the color with the HSL lightness decreased by the amount (0..1, e.g. 0.1 for 10%).
+func (c Color) Darken(amount float64) Color { return c.Lighten(-amount) }
+
+// Saturate returns the color with the HSL saturation increased by the amount (0..1, e.g. 0.1 for 10%).
+func (c Color) Saturate(amount float64) Color {
+	var hsl = c.HSL()
+
+	hsl.S = clamp01(hsl.S + amount)
+
+	return hsl.Color()
+}
+
+// Desaturate returns the color with the HSL saturation decreased by the amount (0..1, e.g. 0.1 for 10%).
+func (c Color) Desaturate(amount float64) Color { return c.Saturate(-amount) }
+
+// Mix returns the mix of two colors, interpolated in the OKLab color space (perceptually uniform). The weight is in
+// the 0..1 range: 0 returns the color itself, 1 returns the other color.
+func (c Color) Mix(other Color, weight float64) Color {
+	var (
+		a, b = c.OKLab(), other.OKLab()
+		w    = clamp01(weight)
+	)
+
+	return OKLab{L: a.L + (b.L-a.L)*w, A: a.A + (b.A-a.A)*w, B: a.B + (b.B-a.B)*w}.Color()
+}
+
+// Over returns the color with the alpha (0..1, where 0 is fully transparent) composited over the background color.
+func (c Color) Over(background Color, alpha float64) Color {
+	var (
+		a          = clamp01(alpha)
+		r, g, b    = c.rgbFloats()
+		br, bg, bb = background.rgbFloats()
+	)
+
+	return colorFromFloats(r*a+br*(1-a), g*a+bg*(1-a), b*a+bb*(1-a))
+}
@@ -0,0 +1,129 @@
+package colors_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"gh.tarampamp.am/colors"
+)
+
+func ExampleColor_Lighten() {
+	var brand, _ = colors.ParseHex("#3b82f6")
+
+	fmt.Println(brand.Lighten(0.1), brand.Darken(0.1), brand.Mix(colors.Color{}, 0.5))
+
+	// output:
+	// #6ca1f8 #0b63f3 #112f5f
+}
+
+func TestParseHex(t *testing.T) {
+	for give, want := range map[string]colors.Color{
+		"#ff8000": {R: 255, G: 128},
+		"FF8000":  {R: 255, G: 128},
+		"#f80":    {R: 255, G: 136},
+		"abc":     {R: 170, G: 187, B: 204},
+		"#000000": {},
+		"#FFFFFF": {R: 255, G: 255, B: 255},
+	} {
+		t.Run(give, func(t *testing.T) {
+			c, err := colors.ParseHex(give)
+
+			assertEqualValues(t, nil, err)
+			assertEqualValues(t, want, c)
+		})
+	}
+
+	for _, give := range []string{"", "#", "#ff", "#fffff", "#fffffff", "#gg0000", "# 00000"} {
+		_, err := colors.ParseHex(give)
+
+		assertTrue(t, errors.Is(err, colors.ErrInvalidHexColor))
+	}
+}
+
+func TestColor_Hex(t *testing.T) {
+	assertEqualValues(t, "#ff8000", colors.Color{R: 255, G: 128}.Hex())
+	assertEqualValues(t, "#000000", colors.Color{}.Hex())
+	assertEqualValues(t, "#0a0b0c", colors.Color{R: 10, G: 11, B: 12}.String())
+}
+
+// testColors is a set of colors for the round-trip conversion tests.
+var testColors = []colors.Color{
+	{}, {R: 255, G: 255, B: 255}, {R: 255}, {G: 255}, {B: 255}, {R: 255, G: 255}, {R: 128, G: 128, B: 128},
+	{R: 59, G: 130, B: 246}, {R: 102, G: 51, B: 153}, {R: 255, G: 165}, {R: 1, G: 2, B: 3}, {R: 250, G: 5, B: 129},
+}
+
+func TestColor_RoundTrip(t *testing.T) {
+	for _, c := range testColors {
+		t.Run(c.Hex(), func(t *testing.T) {
+			assertEqualValues(t, c, c.HSL().Color())
+			assertEqualValues(t, c, c.HSV().Color())
+			assertEqualValues(t, c, c.Lab().Color())
+			assertEqualValues(t, c, c.OKLab().Color())
+			assertEqualValues(t, c, c.OKLCH().Color())
+		})
+	}
+}
+
+func TestColor_Conversions(t *testing.T) {
+	var red = colors.Color{R: 255}
+
+	assertNear(t, 0, red.HSL().H)
+	assertNear(t, 1, red.HSL().S)
+	assertNear(t, 0.5, red.HSL().L)
+
+	assertNear(t, 1, red.HSV().S)
+	assertNear(t, 1, red.HSV().V)
+
+	assertNear(t, 53.2408, red.Lab().L)
+	assertNear(t, 80.0925, red.Lab().A)
+	assertNear(t, 67.2032, red.Lab().B)
+
+	assertNear(t, 0.6280, red.OKLab().L)
+	assertNear(t, 0.2249, red.OKLab().A)
+	assertNear(t, 0.1258, red.OKLab().B)
+
+	assertNear(t, 0.2577, red.OKLCH().C)
+	assertNear(t, 29.2339, red.OKLCH().H)
+
+	var blue = colors.Color{B: 255}
+
+	assertNear(t, 240, blue.HSL().H)
+	assertNear(t, 240, blue.HSV().H)
+
+	assertEqualValues(t, colors.Color{R: 255}, colors.HSL{H: 360, S: 1, L: 0.5}.Color())
+	assertEqualValues(t, colors.Color{R: 255}, colors.HSV{H: -360, S: 1, V: 1}.Color())
+}
+
+func TestColor_Adjustments(t *testing.T) {
+	var c = colors.Color{R: 128, G: 64, B: 64}
+
+	assertNear(t, c.HSL().L+0.2, c.Lighten(0.2).HSL().L)
+	assertNear(t, c.HSL().L-0.2, c.Darken(0.2).HSL().L)
+	assertEqualValues(t, colors.Color{R: 255, G: 255, B: 255}, c.Lighten(1))
+	assertEqualValues(t, colors.Color{}, c.Darken(1))
+
+	assertTrue(t, c.Saturate(0.3).HSL().S > c.HSL().S)
+	assertTrue(t, c.Desaturate(0.3).HSL().S < c.HSL().S)
+	assertEqualValues(t, colors.Color{R: 96, G: 96, B: 96}, c.Desaturate(1))
+
+	var black, white = colors.Color{}, colors.Color{R: 255, G: 255, B: 255}
+
+	assertEqualValues(t, black, black.Mix(white, 0))
+	assertEqualValues(t, white, black.Mix(white, 1))
+	assertEqualValues(t, white, black.Mix(white, 2)) // clamped
+	assertEqualValues(t, colors.Color{R: 99, G: 99, B: 99}, black.Mix(white, 0.5))
+
+	assertEqualValues(t, white, white.Over(black, 1))
+	assertEqualValues(t, black, white.Over(black, 0))
+	assertEqualValues(t, colors.Color{R: 128, G: 128, B: 128}, white.Over(black, 0.5))
+}
+
+func assertNear(t *testing.T, expected, actual float64) {
+	t.Helper()
+
+	if math.Abs(expected-actual) > 1e-3 {
+		t.Errorf("expected %v, actual %v", expected, actual)
+	}
+}
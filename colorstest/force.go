@@ -0,0 +1,51 @@
+package colorstest
+
+import (
+	"strconv"
+	"testing"
+
+	"gh.tarampamp.am/colors"
+)
+
+// forceEnv is the environment variable, set by Force. The testing package forbids the environment changes in the
+// parallel tests, so it's used to detect them (and to forbid the t.Parallel call after Force).
+const forceEnv = "COLORSTEST_FORCE"
+
+// Force sets the global colors state (see colors.Enabled) for the test, and restores the previous state when the
+// test (and its subtests) completes. Since the state is global, Force fails the test if it's parallel (or has
+// parallel ancestors), and the test can not call t.Parallel after Force. Use Context for the parallel tests instead.
+//
+//	func TestOutput(t *testing.T) {
+//		colorstest.Force(t, true)
+//
+//		// ...
+//	}
+func Force(tb testing.TB, enabled bool) {
+	tb.Helper()
+
+	if !setenv(tb, forceEnv, strconv.FormatBool(enabled)) {
+		tb.Fatalf("colorstest.Force can not be used in parallel tests, since the colors state is global "+
+			"(use colorstest.Context instead): %s", tb.Name())
+
+		return
+	}
+
+	var prev = colors.Enabled()
+
+	tb.Cleanup(func() { colors.Enabled(prev) })
+
+	colors.Enabled(enabled)
+}
+
+// setenv sets the environment variable for the test. Returns false if it's not allowed (the test is parallel).
+func setenv(tb testing.TB, key, value string) (ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+
+	tb.Setenv(key, value)
+
+	return true
+}
@@ -0,0 +1,53 @@
+package colorstest_test
+
+import (
+	"strings"
+	"testing"
+
+	"gh.tarampamp.am/colors"
+	"gh.tarampamp.am/colors/colorstest"
+)
+
+func TestForce(t *testing.T) {
+	var initial = colors.Enabled()
+
+	t.Run("enabled", func(t *testing.T) {
+		colorstest.Force(t, true)
+
+		if !colors.Enabled() {
+			t.Error("colors should be enabled")
+		}
+
+		t.Run("nested", func(t *testing.T) {
+			colorstest.Force(t, false)
+
+			if colors.Enabled() {
+				t.Error("colors should be disabled")
+			}
+		})
+
+		if !colors.Enabled() {
+			t.Error("colors state should be restored after the subtest")
+		}
+	})
+
+	if colors.Enabled() != initial {
+		t.Error("colors state should be restored")
+	}
+}
+
+func TestForce_Parallel(t *testing.T) {
+	t.Run("group", func(t *testing.T) {
+		t.Run("parallel", func(t *testing.T) {
+			t.Parallel()
+
+			var tb = &fakeTB{TB: t}
+
+			colorstest.Force(tb, true)
+
+			if len(tb.failures) != 1 || !strings.Contains(tb.failures[0], "can not be used in parallel tests") {
+				t.Errorf("unexpected failures: %q", tb.failures)
+			}
+		})
+	})
+}
@@ -0,0 +1,122 @@
+package colorstest
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gh.tarampamp.am/colors"
+)
+
+// updateFlag is the name of the flag, that rewrites the golden files (`go test ./... -update`).
+const updateFlag = "update"
+
+func init() { //nolint:gochecknoinits // the flag must be registered before the flags parsing
+	if flag.Lookup(updateFlag) == nil {
+		flag.Bool(updateFlag, false, "update the golden files")
+	}
+}
+
+// updateGolden returns true if the golden files should be rewritten.
+func updateGolden() bool {
+	if f := flag.Lookup(updateFlag); f != nil {
+		return f.Value.String() == "true"
+	}
+
+	return false
+}
+
+// Context returns the test context with the colors enabled (see colors.WithEnabled), so the context-aware styling
+// functions (e.g. colors.TextStyle.WrapContext) produce the styled output in this test only, without touching the
+// global colors state (so it's safe for the parallel tests).
+func Context(tb testing.TB) context.Context { return colors.WithEnabled(tb.Context(), true) }
+
+// Golden compares the styled output with the golden file "testdata/<name>.golden", that stores the output in the
+// readable markup form (see Markup). On mismatch, the test fails with the colorized diff. Run the tests with the
+// -update flag to create or rewrite the golden files:
+//
+//	func TestHelp(t *testing.T) {
+//		colorstest.Golden(t, "help", renderHelp(colorstest.Context(t)))
+//	}
+func Golden(tb testing.TB, name, got string) {
+	tb.Helper()
+
+	var (
+		path   = filepath.Join("testdata", name+".golden")
+		markup = Markup(got)
+	)
+
+	if updateGolden() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { //nolint:mnd
+			tb.Fatalf("failed to create the golden file directory: %v", err)
+		}
+
+		if err := os.WriteFile(path, []byte(markup), 0o644); err != nil { //nolint:mnd,gosec
+			tb.Fatalf("failed to write the golden file: %v", err)
+		}
+
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		tb.Fatalf("golden file %s does not exist (run the test with -%s flag to create it)", path, updateFlag)
+
+		return
+	} else if err != nil {
+		tb.Fatalf("failed to read the golden file: %v", err)
+
+		return
+	}
+
+	if string(want) != markup {
+		tb.Errorf("output does not match the golden file %s (-want +got):\n%s", path,
+			Diff(string(want), markup, colors.Enabled()))
+	}
+}
+
+// Diff returns the line-based diff of the strings: removed lines are prefixed with "-", added with "+", and the
+// same lines with " ". The diff is colorized, if the colored flag is set (Golden uses the colors.Enabled state).
+func Diff(want, got string, colored bool) string {
+	var (
+		a, b = strings.Split(want, "\n"), strings.Split(got, "\n")
+		lcs  = make([][]int, len(a)+1) // the longest common subsequence lengths of the a[i:] and b[j:]
+		buf  strings.Builder
+	)
+
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var i, j int
+
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			buf.WriteString(" " + a[i] + "\n")
+			i, j = i+1, j+1
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			buf.WriteString(colors.FgRed.WrapIf(colored, "-"+a[i]) + "\n")
+			i++
+		default:
+			buf.WriteString(colors.FgGreen.WrapIf(colored, "+"+b[j]) + "\n")
+			j++
+		}
+	}
+
+	return buf.String()
+}
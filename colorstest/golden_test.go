@@ -0,0 +1,99 @@
+package colorstest_test
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gh.tarampamp.am/colors"
+	"gh.tarampamp.am/colors/colorstest"
+)
+
+// fakeTB records the test failures.
+type fakeTB struct {
+	testing.TB
+
+	failures []string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...any) {
+	f.failures = append(f.failures, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeTB) Fatalf(format string, args ...any) { f.Errorf(format, args...) }
+
+// report renders the report in the context-aware way (the colors state is taken from the context).
+func report(t *testing.T) string {
+	var ctx = colorstest.Context(t)
+
+	return colors.Bold.WrapContext(ctx, "Report") + "\n" +
+		"status: " + colors.FgGreen.WrapContext(ctx, "ok") + "\n"
+}
+
+func TestGolden(t *testing.T) {
+	t.Parallel() // the global colors state is not used
+
+	colorstest.Golden(t, "report", report(t))
+
+	var tb = &fakeTB{TB: t}
+
+	colorstest.Golden(tb, "report", strings.Replace(report(t), "ok", "failed", 1))
+
+	if len(tb.failures) != 1 {
+		t.Fatalf("expected one failure, got %q", tb.failures)
+	}
+
+	for _, want := range []string{"testdata/report.golden", "-status: [green]ok[/]", "+status: [green]failed[/]"} {
+		if !strings.Contains(tb.failures[0], want) {
+			t.Errorf("expected %q in the failure %q", want, tb.failures[0])
+		}
+	}
+
+	tb.failures = nil
+
+	colorstest.Golden(tb, "not-exists", "")
+
+	if len(tb.failures) != 1 || !strings.Contains(tb.failures[0], "-update flag to create it") {
+		t.Errorf("unexpected failures: %q", tb.failures)
+	}
+}
+
+func TestGolden_Update(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	if err := flag.Set("update", "true"); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = flag.Set("update", "false") })
+
+	colorstest.Golden(t, "nested/out", "\x1b[1mx\x1b[0m")
+
+	got, err := os.ReadFile(filepath.Join("testdata", "nested", "out.golden"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "[bold]x[/]"; string(got) != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	const want = " a\n-b\n+B\n c\n+d\n"
+
+	if got := colorstest.Diff("a\nb\nc", "a\nB\nc\nd", false); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	const wantColored = " a\n\x1b[31m-b\x1b[39m\n\x1b[32m+B\x1b[39m\n c\n\x1b[32m+d\x1b[39m\n"
+
+	if got := colorstest.Diff("a\nb\nc", "a\nB\nc\nd", true); got != wantColored {
+		t.Errorf("expected %q, got %q", wantColored, got)
+	}
+}
@@ -0,0 +1,125 @@
+package colorstest
+
+import (
+	"strconv"
+	"strings"
+
+	"gh.tarampamp.am/colors"
+	"gh.tarampamp.am/colors/ansi"
+)
+
+// styleNames are the markup names of the style bits (in the markup order).
+var styleNames = [...]struct { //nolint:gochecknoglobals // read-only
+	style colors.TextStyle
+	name  string
+}{
+	{colors.Bold, "bold"}, {colors.Faint, "faint"}, {colors.Italic, "italic"}, {colors.Underline, "underline"},
+	{colors.Blinking, "blink"}, {colors.Reverse, "reverse"}, {colors.Invisible, "invisible"},
+	{colors.Strike, "strike"},
+}
+
+// colorNames are the markup names of the colors (in the TextStyle bits order).
+var colorNames = [...]string{ //nolint:gochecknoglobals // read-only
+	"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white",
+}
+
+// MarkupStyle returns the markup names of the style, separated with commas (e.g. "bold,red,bg-bright-blue").
+func MarkupStyle(style colors.TextStyle) string {
+	var names = make([]string, 0, 3) //nolint:mnd
+
+	for _, n := range styleNames {
+		if style.Has(n.style) {
+			names = append(names, n.name)
+		}
+	}
+
+	for i, name := range colorNames {
+		if style.Has(colors.FgBlack << i) {
+			if style.Has(colors.FgBright) {
+				name = "bright-" + name
+			}
+
+			names = append(names, name)
+		}
+	}
+
+	for i, name := range colorNames {
+		if style.Has(colors.BgBlack << i) {
+			if style.Has(colors.BgBright) {
+				name = "bright-" + name
+			}
+
+			names = append(names, "bg-"+name)
+		}
+	}
+
+	return strings.Join(names, ",")
+}
+
+// Markup converts the styled text (with the escape sequences) into the readable markup, e.g. "\x1b[1;31merror\x1b[0m"
+// becomes "[bold,red]error[/]" (see MarkupStyle for the style names). Tags are closed at the line breaks, so every line
+// is self-contained. Literal "[" characters are doubled, control characters (except the line breaks and tabs) and other
+// escape sequences are rendered as Go-escaped strings (e.g. "\r" or "[\x1b[2K]"). The 256 and RGB colors are
+// approximated with the nearest ANSI colors.
+func Markup(s string) string {
+	var (
+		buf    strings.Builder
+		style  colors.TextStyle
+		opened string // the markup of the opened style tag
+	)
+
+	buf.Grow(len(s))
+
+	ansi.Parse([]byte(s), func(t *ansi.Token) {
+		switch t.Kind {
+		case ansi.TokenSGR:
+			style = applySGR(style, t.Params)
+
+			return
+		case ansi.TokenControl:
+			if t.Final == '\n' && opened != "" { // every line is self-contained
+				buf.WriteString("[/]")
+				opened = ""
+			}
+
+			if t.Final == '\n' || t.Final == '\t' {
+				buf.WriteByte(t.Final)
+			} else {
+				buf.WriteString(quote(t.Raw))
+			}
+
+			return
+		}
+
+		if markup := MarkupStyle(style); markup != opened {
+			if opened != "" {
+				buf.WriteString("[/]")
+			}
+
+			if markup != "" {
+				buf.WriteString("[" + markup + "]")
+			}
+
+			opened = markup
+		}
+
+		if t.Kind == ansi.TokenText {
+			buf.WriteString(strings.ReplaceAll(string(t.Data), "[", "[["))
+		} else {
+			buf.WriteString("[" + quote(t.Raw) + "]")
+		}
+	})
+
+	if opened != "" {
+		buf.WriteString("[/]")
+	}
+
+	return buf.String()
+}
+
+// quote returns the Go-escaped string (without the quotes).
+func quote(b []byte) string {
+	var q = strconv.Quote(string(b))
+
+	return q[1 : len(q)-1]
+}
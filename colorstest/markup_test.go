@@ -0,0 +1,60 @@
+package colorstest_test
+
+import (
+	"fmt"
+	"testing"
+
+	"gh.tarampamp.am/colors"
+	"gh.tarampamp.am/colors/colorstest"
+)
+
+func ExampleMarkup() {
+	fmt.Println(colorstest.Markup("\x1b[1;31merror\x1b[0m: file [a.txt] not found"))
+
+	// output:
+	// [bold,red]error[/]: file [[a.txt] not found
+}
+
+func TestMarkup(t *testing.T) {
+	for name, tt := range map[string]struct {
+		give, want string
+	}{
+		"plain":         {"foo\tbar\nbaz", "foo\tbar\nbaz"},
+		"styled":        {"\x1b[1mfoo\x1b[22m bar", "[bold]foo[/] bar"},
+		"not closed":    {"\x1b[4;35mfoo", "[underline,magenta]foo[/]"},
+		"style change":  {"\x1b[1ma\x1b[31mb\x1b[22mc\x1b[0m", "[bold]a[/][bold,red]b[/][red]c[/]"},
+		"same style":    {"\x1b[31ma\x1b[31mb\x1b[39m\x1b[0m", "[red]ab[/]"},
+		"bright":        {"\x1b[92;104mx\x1b[0m", "[bright-green,bg-bright-blue]x[/]"},
+		"background":    {"\x1b[30;47mx\x1b[m", "[black,bg-white]x[/]"},
+		"rgb":           {"\x1b[38;2;0;0;238mx\x1b[m", "[blue]x[/]"},
+		"empty styled":  {"\x1b[1m\x1b[0mx", "x"},
+		"controls":      {"10%\r\x1b[2K20%\a", `10%\r[\x1b[2K]20%\a`},
+		"styled csi":    {"\x1b[7m\x1b[?25lx\x1b[0m", `[reverse][\x1b[?25l]x[/]`},
+		"osc hyperlink": {"\x1b]8;;http://x\x1b\\link\x1b]8;;\x1b\\", `[\x1b]8;;http://x\x1b\\]link[\x1b]8;;\x1b\\]`},
+		"escaped":       {"[bold]", "[[bold]"},
+		"multi-line":    {"\x1b[31ma\nb\x1b[0m\n", "[red]a[/]\n[red]b[/]\n"},
+		"all attrs": {
+			"\x1b[1;2;3;4;5;7;8;9mx", "[bold,faint,italic,underline,blink,reverse,invisible,strike]x[/]",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := colorstest.Markup(tt.give); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestMarkupStyle(t *testing.T) {
+	for give, want := range map[colors.TextStyle]string{
+		0:                                  "",
+		colors.Reset:                       "",
+		colors.FgYellow | colors.BgCyan:    "yellow,bg-cyan",
+		colors.Strike | colors.FgDefault:   "strike",
+		colors.BgMagenta | colors.BgBright: "bg-bright-magenta",
+	} {
+		if got := colorstest.MarkupStyle(give); got != want {
+			t.Errorf("%032b: expected %q, got %q", give, want, got)
+		}
+	}
+}
@@ -0,0 +1,519 @@
+// Package colorstest provides the helpers for testing the styled (colored) output: the virtual terminal screen
+// emulator, to assert on the rendered cells instead of the raw escape sequences, the golden files helpers, that
+// store the output in the readable markup form, and the colors state helpers (see Force and Context).
+package colorstest
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"gh.tarampamp.am/colors"
+	"gh.tarampamp.am/colors/ansi"
+	"gh.tarampamp.am/colors/internal/grapheme"
+)
+
+// Cell is a screen cell.
+type Cell struct {
+	Rune  rune             // Character (0 for the empty cell, or the second half of the wide character)
+	Style colors.TextStyle // Character style (the default colors are represented by the zero color bits)
+	Wide  bool             // The character occupies two cells (the next cell is its second half)
+}
+
+// cursor is a cursor position and the style (saved and restored together).
+type cursor struct {
+	row, col int
+	style    colors.TextStyle
+}
+
+// Screen is an in-memory virtual terminal (VT) screen emulator. The written data is parsed (see ansi.Parser), and
+// the screen maintains the grid of cells with the characters and their styles. It understands the SGR sequences
+// (256 and RGB colors are approximated with the nearest ANSI colors), cursor movement, erasing, scrolling, line
+// wrapping and the alternate screen. Like a terminal (with the tty driver), it treats the line feed as the new line
+// (the cursor is moved to the beginning of the next line). Zero-width characters (e.g. combining marks) are ignored.
+//
+//	var s = colorstest.NewScreen(80, 24)
+//
+//	fmt.Fprint(s, colors.FgRed.Wrap("Error"), ": boom")
+//
+//	if c := s.Cell(0, 0); c.Rune != 'E' || c.Style != colors.FgRed {
+//		t.Errorf("unexpected cell: %+v", c)
+//	}
+//
+// Screen is not goroutine-safe.
+type Screen struct {
+	width, height int
+	cells         [][]Cell
+	cur           cursor
+	saved         cursor
+	wrapPending   bool // the cursor is at the last column, and the next character wraps the line
+	top, bottom   int  // scrolling region (inclusive, 0-based)
+	hidden        bool // the cursor is hidden
+	noWrap        bool // auto-wrap mode is disabled
+	main          *Screen
+	parser        *ansi.Parser
+}
+
+// NewScreen creates a new screen of the given size (in cells).
+func NewScreen(width, height int) *Screen {
+	var s = &Screen{width: max(width, 1), height: max(height, 1)}
+
+	s.reset()
+	s.parser = ansi.NewParser(s.handle)
+
+	return s
+}
+
+// reset resets the screen to the initial state.
+func (s *Screen) reset() {
+	s.cells = make([][]Cell, s.height)
+
+	for i := range s.cells {
+		s.cells[i] = make([]Cell, s.width)
+	}
+
+	s.cur, s.saved, s.wrapPending = cursor{}, cursor{}, false
+	s.top, s.bottom = 0, s.height-1
+	s.hidden, s.noWrap, s.main = false, false, nil
+}
+
+// Write writes the data to the screen. It never returns an error. Implements the io.Writer interface.
+func (s *Screen) Write(p []byte) (int, error) { return s.parser.Write(p) }
+
+// WriteString writes the string to the screen. Implements the io.StringWriter interface.
+func (s *Screen) WriteString(str string) (int, error) { return s.Write([]byte(str)) }
+
+// Size returns the screen size (in cells).
+func (s *Screen) Size() (width, height int) { return s.width, s.height }
+
+// Cursor returns the cursor position (0-based).
+func (s *Screen) Cursor() (col, row int) { return s.cur.col, s.cur.row }
+
+// CursorVisible returns true if the cursor is not hidden.
+func (s *Screen) CursorVisible() bool { return !s.hidden }
+
+// Style returns the current style (used for the next written characters).
+func (s *Screen) Style() colors.TextStyle { return s.cur.style }
+
+// Cell returns the cell at the column and row (0-based). The empty cell is returned for the out of bounds position.
+func (s *Screen) Cell(col, row int) Cell {
+	if row < 0 || row >= s.height || col < 0 || col >= s.width {
+		return Cell{}
+	}
+
+	return s.cells[row][col]
+}
+
+// Line returns the plain text of the row (trailing spaces are trimmed).
+func (s *Screen) Line(row int) string {
+	if row < 0 || row >= s.height {
+		return ""
+	}
+
+	return s.line(row, false)
+}
+
+// String returns the plain text snapshot of the screen: the lines are trimmed (trailing spaces and trailing empty
+// lines are removed) and separated with line breaks.
+func (s *Screen) String() string { return s.snapshot(false) }
+
+// Styled returns the styled text snapshot of the screen (the same as String, but the styles are rendered using the
+// SGR sequences, regardless of the colors.Enabled state).
+func (s *Screen) Styled() string { return s.snapshot(true) }
+
+// snapshot returns the screen snapshot.
+func (s *Screen) snapshot(styled bool) string {
+	var lines = make([]string, s.height)
+
+	for row := range lines {
+		lines[row] = s.line(row, styled)
+	}
+
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// line returns the row text (trailing unstyled spaces are trimmed).
+func (s *Screen) line(row int, styled bool) string {
+	var (
+		cells = s.cells[row]
+		end   = len(cells)
+	)
+
+	for end > 0 && (cells[end-1].Rune == 0 || cells[end-1].Rune == ' ') && (!styled || cells[end-1].Style == 0) {
+		if end > 1 && cells[end-2].Wide { // the second half of the wide character
+			break
+		}
+
+		end--
+	}
+
+	var (
+		buf   strings.Builder
+		style colors.TextStyle
+	)
+
+	for col := 0; col < end; col++ {
+		var c = cells[col]
+
+		if col > 0 && cells[col-1].Wide {
+			continue
+		}
+
+		if styled && c.Style != style {
+			if style != 0 {
+				buf.WriteString("\x1b[0m")
+			}
+
+			var start, _ = c.Style.ColorCodes()
+
+			buf.WriteString(start)
+			style = c.Style
+		}
+
+		if c.Rune == 0 {
+			buf.WriteByte(' ')
+		} else {
+			buf.WriteRune(c.Rune)
+		}
+	}
+
+	if style != 0 {
+		buf.WriteString("\x1b[0m")
+	}
+
+	return buf.String()
+}
+
+// handle handles the parsed token.
+func (s *Screen) handle(t *ansi.Token) {
+	switch t.Kind {
+	case ansi.TokenText:
+		for data := t.Data; len(data) > 0; {
+			var r, size = utf8.DecodeRune(data)
+
+			s.print(r)
+			data = data[size:]
+		}
+	case ansi.TokenControl:
+		s.control(t.Final)
+	case ansi.TokenESC:
+		s.esc(t)
+	case ansi.TokenSGR:
+		s.cur.style = applySGR(s.cur.style, t.Params)
+	case ansi.TokenCSI:
+		s.csi(t)
+	}
+}
+
+// print prints the character at the cursor position, and moves the cursor.
+func (s *Screen) print(r rune) {
+	var width = grapheme.Width(string(r))
+
+	if width == 0 {
+		return
+	} else if width > s.width { // the wide character does not fit the screen
+		width = 1
+	}
+
+	if s.wrapPending || s.cur.col+width > s.width {
+		if s.noWrap {
+			s.cur.col = s.width - width
+		} else {
+			s.cur.col = 0
+			s.lineFeed()
+		}
+	}
+
+	s.wrapPending = false
+	s.clearWide(s.cur.row, s.cur.col)
+
+	if width == 2 && s.cur.col+1 < s.width { //nolint:mnd
+		s.clearWide(s.cur.row, s.cur.col+1)
+		s.cells[s.cur.row][s.cur.col] = Cell{Rune: r, Style: s.cur.style, Wide: true}
+		s.cells[s.cur.row][s.cur.col+1] = Cell{Style: s.cur.style}
+	} else {
+		s.cells[s.cur.row][s.cur.col] = Cell{Rune: r, Style: s.cur.style}
+	}
+
+	if s.cur.col+width >= s.width {
+		s.cur.col, s.wrapPending = s.width-1, !s.noWrap
+	} else {
+		s.cur.col += width
+	}
+}
+
+// clearWide clears the wide character, that the cell (the first or the second half) belongs to.
+func (s *Screen) clearWide(row, col int) {
+	var cells = s.cells[row]
+
+	if cells[col].Wide && col+1 < s.width {
+		cells[col+1] = Cell{}
+	}
+
+	if col > 0 && cells[col-1].Wide {
+		cells[col-1] = Cell{}
+	}
+}
+
+// control executes the control character.
+func (s *Screen) control(c byte) {
+	switch c {
+	case '\r':
+		s.cur.col, s.wrapPending = 0, false
+	case '\n', '\v', '\f':
+		s.cur.col = 0
+		s.lineFeed()
+	case '\b':
+		s.cur.col, s.wrapPending = max(s.cur.col-1, 0), false
+	case '\t':
+		s.cur.col, s.wrapPending = min((s.cur.col/8+1)*8, s.width-1), false //nolint:mnd
+	}
+}
+
+// lineFeed moves the cursor down, scrolling the region up at the bottom margin.
+func (s *Screen) lineFeed() {
+	s.wrapPending = false
+
+	switch {
+	case s.cur.row == s.bottom:
+		s.scrollUp(1)
+	case s.cur.row < s.height-1:
+		s.cur.row++
+	}
+}
+
+// reverseIndex moves the cursor up, scrolling the region down at the top margin.
+func (s *Screen) reverseIndex() {
+	s.wrapPending = false
+
+	switch {
+	case s.cur.row == s.top:
+		s.scrollDown(1)
+	case s.cur.row > 0:
+		s.cur.row--
+	}
+}
+
+// scrollUp scrolls the scrolling region up by n lines.
+func (s *Screen) scrollUp(n int) {
+	s.deleteLines(s.top, n)
+}
+
+// scrollDown scrolls the scrolling region down by n lines.
+func (s *Screen) scrollDown(n int) {
+	s.insertLines(s.top, n)
+}
+
+// deleteLines deletes n lines starting from the row (within the scrolling region), the lines below are moved up.
+func (s *Screen) deleteLines(row, n int) {
+	n = min(n, s.bottom-row+1)
+
+	for i := 0; i < n; i++ {
+		var line = s.cells[row]
+
+		copy(s.cells[row:s.bottom+1], s.cells[row+1:s.bottom+1])
+		clear(line)
+		s.cells[s.bottom] = line
+	}
+}
+
+// insertLines inserts n empty lines at the row (within the scrolling region), the lines below are moved down.
+func (s *Screen) insertLines(row, n int) {
+	n = min(n, s.bottom-row+1)
+
+	for i := 0; i < n; i++ {
+		var line = s.cells[s.bottom]
+
+		copy(s.cells[row+1:s.bottom+1], s.cells[row:s.bottom])
+		clear(line)
+		s.cells[row] = line
+	}
+}
+
+// moveTo moves the cursor to the position (clamped to the screen).
+func (s *Screen) moveTo(col, row int) {
+	s.cur.col, s.cur.row = min(max(col, 0), s.width-1), min(max(row, 0), s.height-1)
+	s.wrapPending = false
+}
+
+// esc executes the escape sequence.
+func (s *Screen) esc(t *ansi.Token) {
+	if len(t.Intermediates) > 0 {
+		return // character set designations, etc.
+	}
+
+	switch t.Final {
+	case '7': // DECSC
+		s.saved = s.cur
+	case '8': // DECRC
+		s.cur, s.wrapPending = s.saved, false
+	case 'D': // IND
+		s.lineFeed()
+	case 'E': // NEL
+		s.cur.col = 0
+		s.lineFeed()
+	case 'M': // RI
+		s.reverseIndex()
+	case 'c': // RIS
+		s.reset()
+	}
+}
+
+// csi executes the control sequence.
+func (s *Screen) csi(t *ansi.Token) { //nolint:funlen,gocyclo
+	if len(t.Intermediates) > 0 {
+		return
+	}
+
+	if t.Prefix == '?' {
+		s.privateMode(t.Params, t.Final == 'h')
+
+		return
+	} else if t.Prefix != 0 {
+		return
+	}
+
+	var (
+		p = t.Params
+		n = max(p.Get(0, 1), 1) // the count parameter (0 is treated as 1)
+	)
+
+	switch t.Final {
+	case 'A': // CUU
+		s.moveTo(s.cur.col, s.cur.row-n)
+	case 'B', 'e': // CUD, VPR
+		s.moveTo(s.cur.col, s.cur.row+n)
+	case 'C', 'a': // CUF, HPR
+		s.moveTo(s.cur.col+n, s.cur.row)
+	case 'D': // CUB
+		s.moveTo(s.cur.col-n, s.cur.row)
+	case 'E': // CNL
+		s.moveTo(0, s.cur.row+n)
+	case 'F': // CPL
+		s.moveTo(0, s.cur.row-n)
+	case 'G', '`': // CHA, HPA
+		s.moveTo(n-1, s.cur.row)
+	case 'd': // VPA
+		s.moveTo(s.cur.col, n-1)
+	case 'H', 'f': // CUP, HVP
+		s.moveTo(max(p.Get(1, 1), 1)-1, n-1)
+	case 'J': // ED
+		s.eraseDisplay(p.Get(0, 0))
+	case 'K': // EL
+		s.eraseLine(p.Get(0, 0))
+	case 'X': // ECH
+		s.erase(s.cur.row, s.cur.col, min(s.cur.col+n, s.width))
+	case '@': // ICH
+		var line = s.cells[s.cur.row]
+
+		n = min(n, s.width-s.cur.col)
+		copy(line[s.cur.col+n:], line[s.cur.col:])
+		clear(line[s.cur.col : s.cur.col+n])
+	case 'P': // DCH
+		var line = s.cells[s.cur.row]
+
+		n = min(n, s.width-s.cur.col)
+		copy(line[s.cur.col:], line[s.cur.col+n:])
+		clear(line[s.width-n:])
+	case 'L': // IL
+		if s.cur.row >= s.top && s.cur.row <= s.bottom {
+			s.insertLines(s.cur.row, n)
+			s.cur.col = 0
+		}
+	case 'M': // DL
+		if s.cur.row >= s.top && s.cur.row <= s.bottom {
+			s.deleteLines(s.cur.row, n)
+			s.cur.col = 0
+		}
+	case 'S': // SU
+		s.scrollUp(n)
+	case 'T': // SD
+		s.scrollDown(n)
+	case 'r': // DECSTBM
+		var top, bottom = max(p.Get(0, 1), 1) - 1, min(max(p.Get(1, s.height), 1), s.height) - 1
+
+		if top < bottom {
+			s.top, s.bottom = top, bottom
+			s.moveTo(0, 0)
+		}
+	case 's': // SCOSC
+		s.saved = s.cur
+	case 'u': // SCORC
+		s.cur, s.wrapPending = s.saved, false
+	}
+}
+
+// privateMode sets or resets the DEC private modes.
+func (s *Screen) privateMode(params ansi.Params, set bool) {
+	for i := range params {
+		switch params.Get(i, 0) {
+		case 7: //nolint:mnd // DECAWM
+			s.noWrap = !set
+		case 25: //nolint:mnd // DECTCEM
+			s.hidden = !set
+		case 1049: //nolint:mnd // alternate screen buffer
+			s.altScreen(set)
+		}
+	}
+}
+
+// altScreen switches to the alternate screen buffer (or back to the main one).
+func (s *Screen) altScreen(enter bool) {
+	switch {
+	case enter && s.main == nil:
+		var main = *s
+
+		main.parser = nil
+		s.reset()
+		s.main, s.cur, s.hidden, s.noWrap = &main, main.cur, main.hidden, main.noWrap
+	case !enter && s.main != nil:
+		var main = s.main
+
+		s.cells, s.cur, s.saved, s.top, s.bottom = main.cells, main.cur, main.saved, main.top, main.bottom
+		s.main, s.wrapPending = nil, false
+	}
+}
+
+// erase erases the cells of the row in the [from, to) range.
+func (s *Screen) erase(row, from, to int) {
+	if from < to {
+		clear(s.cells[row][from:to])
+	}
+}
+
+// eraseLine erases the line (or its part).
+func (s *Screen) eraseLine(mode int) {
+	switch mode {
+	case 0:
+		s.erase(s.cur.row, s.cur.col, s.width)
+	case 1:
+		s.erase(s.cur.row, 0, s.cur.col+1)
+	case 2: //nolint:mnd
+		s.erase(s.cur.row, 0, s.width)
+	}
+}
+
+// eraseDisplay erases the screen (or its part).
+func (s *Screen) eraseDisplay(mode int) {
+	switch mode {
+	case 0:
+		s.eraseLine(0)
+
+		for row := s.cur.row + 1; row < s.height; row++ {
+			s.erase(row, 0, s.width)
+		}
+	case 1:
+		s.eraseLine(1)
+
+		for row := 0; row < s.cur.row; row++ {
+			s.erase(row, 0, s.width)
+		}
+	case 2, 3: //nolint:mnd
+		for row := 0; row < s.height; row++ {
+			s.erase(row, 0, s.width)
+		}
+	}
+}
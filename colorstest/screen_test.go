@@ -0,0 +1,178 @@
+package colorstest_test
+
+import (
+	"fmt"
+	"testing"
+
+	"gh.tarampamp.am/colors"
+	"gh.tarampamp.am/colors/ansi"
+	"gh.tarampamp.am/colors/colorstest"
+)
+
+func ExampleScreen() {
+	var s = colorstest.NewScreen(20, 5)
+
+	_, _ = fmt.Fprint(s, "\x1b[1;31mError\x1b[0m: boom\n")
+	_, _ = fmt.Fprint(s, "progress 10%\rprogress 100%")
+
+	var c = s.Cell(3, 0)
+
+	fmt.Printf("%c %t\n", c.Rune, c.Style == colors.Bold|colors.FgRed)
+	fmt.Println(s)
+
+	// output:
+	// o true
+	// Error: boom
+	// progress 100%
+}
+
+func TestScreen_Text(t *testing.T) {
+	for name, tt := range map[string]struct {
+		give       string
+		want       string
+		wantCursor [2]int
+	}{
+		"empty":            {"", "", [2]int{0, 0}},
+		"new lines":        {"a\nb\r\nc", "a\nb\nc", [2]int{1, 2}},
+		"wrap":             {"abcdefgh", "abcde\nfgh", [2]int{3, 1}},
+		"exact width":      {"abcde\nf", "abcde\nf", [2]int{1, 1}},
+		"pending wrap":     {"abcde\rx", "xbcde", [2]int{1, 0}},
+		"scroll":           {"1\n2\n3\n4", "2\n3\n4", [2]int{1, 2}},
+		"backspace":        {"ab\bc", "ac", [2]int{2, 0}},
+		"tab":              {"a\tb", "a   b", [2]int{4, 0}},
+		"wide":             {"日本語", "日本\n語", [2]int{2, 1}},
+		"wide overwritten": {"日x\rab", "abx", [2]int{2, 0}},
+		"combining":        {"éx", "ex", [2]int{2, 0}},
+		"cursor position":  {"\x1b[2;3Hx", "\n  x", [2]int{3, 1}},
+		"cursor moves":     {"abc\x1b[2D\x1b[1Bx\x1b[Ay", "aby\n x", [2]int{3, 0}},
+		"cursor clamped":   {"\x1b[99;99Hx\x1b[99Ay", "    y\n\n    x", [2]int{4, 0}},
+		"column":           {"abcd\x1b[2Gx", "axcd", [2]int{2, 0}},
+		"erase line":       {"abcd\x1b[2D\x1b[K", "ab", [2]int{2, 0}},
+		"erase line start": {"abcd\x1b[2D\x1b[1K", "   d", [2]int{2, 0}},
+		"erase display":    {"ab\ncd\x1b[2J", "", [2]int{2, 1}},
+		"erase below":      {"ab\ncd\nef\x1b[2;2H\x1b[J", "ab\nc", [2]int{1, 1}},
+		"erase chars":      {"abcd\x1b[3G\x1b[5X", "ab", [2]int{2, 0}},
+		"insert chars":     {"abcd\x1b[2G\x1b[2@", "a  bc", [2]int{1, 0}},
+		"delete chars":     {"abcd\x1b[2G\x1b[2P", "ad", [2]int{1, 0}},
+		"insert lines":     {"1\n2\n3\x1b[2H\x1b[L", "1\n\n2", [2]int{0, 1}},
+		"delete lines":     {"1\n2\n3\x1b[1H\x1b[M", "2\n3", [2]int{0, 0}},
+		"save and restore": {"ab\x1b7\ncd\x1b8x\x1b[s\x1b[3Hy\x1b[uz", "abxz\ncd\ny", [2]int{4, 0}},
+		"reverse index":    {"a\x1bMb", " b\na", [2]int{2, 0}},
+		"next line":        {"a\x1bEb", "a\nb", [2]int{1, 1}},
+		"scroll up":        {"1\n2\n3\x1b[S", "2\n3", [2]int{1, 2}},
+		"scroll down":      {"1\n2\n3\x1b[2T", "\n\n1", [2]int{1, 2}},
+		"scroll region":    {"1\n2\n3\x1b[1;2r\x1b[2Hx\ny", "x\ny\n3", [2]int{1, 1}},
+		"no auto-wrap":     {"\x1b[?7labcdefg", "abcdg", [2]int{4, 0}},
+		"reset":            {"abc\x1bcx", "x", [2]int{1, 0}},
+		"ignored":          {"\x1b]0;title\a\x1b(Ba\x1b[>1ub\a", "ab", [2]int{2, 0}},
+		"progress bar": {
+			"\r" + ansi.CursorUp(0) + "10%" + ansi.EraseLine(ansi.EraseToEnd) + "\r5" + ansi.EraseLine(ansi.EraseToEnd),
+			"5", [2]int{1, 0},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var s = colorstest.NewScreen(5, 3)
+
+			_, _ = s.WriteString(tt.give)
+
+			if got := s.String(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+
+			if col, row := s.Cursor(); col != tt.wantCursor[0] || row != tt.wantCursor[1] {
+				t.Errorf("expected cursor at %v, got [%d %d]", tt.wantCursor, col, row)
+			}
+		})
+	}
+}
+
+func TestScreen_Style(t *testing.T) {
+	for name, tt := range map[string]struct {
+		give string
+		want colors.TextStyle
+	}{
+		"none":            {"x", 0},
+		"bold red":        {"\x1b[1;31mx", colors.Bold | colors.FgRed},
+		"reset":           {"\x1b[1;31m\x1b[mx", 0},
+		"partial reset":   {"\x1b[1;3;4;31;42m\x1b[22;24;39mx", colors.Italic | colors.BgGreen},
+		"bright":          {"\x1b[91;104mx", colors.FgRed | colors.FgBright | colors.BgBlue | colors.BgBright},
+		"color override":  {"\x1b[91m\x1b[32mx", colors.FgGreen},
+		"bg reset":        {"\x1b[41;49mx", 0},
+		"256 color":       {"\x1b[38;5;9mx", colors.FgRed | colors.FgBright},
+		"256 color cube":  {"\x1b[48;5;21mx", colors.BgBlue},
+		"rgb color":       {"\x1b[38;2;200;0;0;1mx", colors.FgRed | colors.Bold},
+		"rgb colon":       {"\x1b[38:2::0:205:0;4mx", colors.FgGreen | colors.Underline},
+		"rgb colon short": {"\x1b[48:2:0:0:238mx", colors.BgBlue},
+		"invalid color":   {"\x1b[38;7;1mx", colors.Bold},
+		"truncated color": {"\x1b[38;5mx", 0},
+		"attributes":      {"\x1b[2;5;7;8mx", colors.Faint | colors.Blinking | colors.Reverse | colors.Invisible},
+		"attrs resets":    {"\x1b[2;3;5;7;8;9m\x1b[23;25;27;28;29mx", colors.Faint},
+		"reset and set":   {"\x1b[4m\x1b[0;1;31mx", colors.Bold | colors.FgRed},
+		"underline color": {"\x1b[58;5;1;3mx", colors.Italic},
+		"unsupported":     {"\x1b[6;21;26mx", 0},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var s = colorstest.NewScreen(5, 1)
+
+			_, _ = s.WriteString(tt.give)
+
+			if got := s.Cell(0, 0); got.Rune != 'x' || got.Style != tt.want {
+				t.Errorf("expected 'x' with %032b, got %q with %032b", tt.want, got.Rune, got.Style)
+			}
+		})
+	}
+}
+
+func TestScreen_Styled(t *testing.T) {
+	var s = colorstest.NewScreen(10, 3)
+
+	_, _ = s.WriteString("\x1b[1mab\x1b[0mc\n\x1b[41m  \x1b[0m\n\x1b[31m日\x1b[0m")
+
+	const want = "\x1b[1mab\x1b[0mc\n\x1b[41m  \x1b[0m\n\x1b[31m日\x1b[0m"
+
+	if got := s.Styled(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	if c := s.Cell(0, 2); !c.Wide || c.Rune != '日' {
+		t.Errorf("unexpected cell: %+v", c)
+	}
+
+	if c := s.Cell(-1, 100); c != (colorstest.Cell{}) {
+		t.Errorf("unexpected cell: %+v", c)
+	}
+
+	if got := s.Line(1); got != "" {
+		t.Errorf("unexpected line: %q", got)
+	}
+}
+
+func TestScreen_Modes(t *testing.T) {
+	var s = colorstest.NewScreen(10, 2)
+
+	if w, h := s.Size(); w != 10 || h != 2 {
+		t.Errorf("unexpected size: %dx%d", w, h)
+	}
+
+	_, _ = s.WriteString("main\x1b[1m" + ansi.HideCursor)
+
+	if s.CursorVisible() || s.Style() != colors.Bold {
+		t.Error("the cursor should be hidden, and the style should be bold")
+	}
+
+	_, _ = s.WriteString(ansi.EnterAltScreen + "alt")
+
+	if got := s.String(); got != "    alt" {
+		t.Errorf("unexpected alternate screen: %q", got)
+	}
+
+	_, _ = s.WriteString(ansi.ExitAltScreen + ansi.ShowCursor + "!")
+
+	if got := s.String(); got != "main!" {
+		t.Errorf("unexpected main screen: %q", got)
+	}
+
+	if !s.CursorVisible() {
+		t.Error("the cursor should be visible")
+	}
+}
@@ -0,0 +1,93 @@
+package colorstest
+
+import (
+	"gh.tarampamp.am/colors"
+	"gh.tarampamp.am/colors/ansi"
+)
+
+// applySGR applies the SGR parameters to the style (see colors.TextStyle.ApplyCodes), the default colors are
+// represented by the absence of colors. The 256 and RGB colors are approximated with the nearest ANSI colors (of the
+// xterm palette), the unsupported codes are ignored.
+func applySGR(style colors.TextStyle, params ansi.Params) colors.TextStyle {
+	if len(params) == 0 {
+		return 0
+	}
+
+	for i := 0; i < len(params); i++ {
+		if params[i].Sub {
+			continue
+		}
+
+		switch code := params.Get(i, 0); code {
+		case 38, 48, 58: // extended colors, approximated with the nearest ANSI colors (the underline color is ignored)
+			var color, n, ok = extendedColor(params, i)
+
+			i += n
+
+			switch {
+			case !ok:
+			case code == 38: //nolint:mnd
+				style = style&^colors.FgMask | colors.XtermPalette.NearestFg(color)
+			case code == 48: //nolint:mnd
+				style = style&^colors.BgMask | colors.XtermPalette.NearestBg(color)
+			}
+		default:
+			style, _ = style.ApplyCodes(code)
+			style &^= colors.FgDefault | colors.BgDefault
+		}
+	}
+
+	return style
+}
+
+// extendedColor decodes the extended color ("38;5;n", "38;2;r;g;b" or their colon forms, including
+// "38:2:colorspace:r:g:b") of the i-th parameter. Returns the color, the number of the consumed parameters and
+// false, if it's not the 256 or RGB color (or parameters are missing).
+func extendedColor(params ansi.Params, i int) (colors.Color, int, bool) {
+	if i+1 >= len(params) {
+		return colors.Color{}, 0, false
+	}
+
+	var value = func(j int) uint8 { return uint8(min(max(params.Get(i+j, 0), 0), 255)) } //nolint:mnd,gosec
+
+	var (
+		colon = params[i+1].Sub
+		n     = len(params) - i - 1 // the number of the color parameters (including the mode)
+	)
+
+	if colon {
+		n = 0
+
+		for i+1+n < len(params) && params[i+1+n].Sub {
+			n++
+		}
+	}
+
+	switch params.Get(i+1, 0) {
+	case 5: //nolint:mnd
+		if !colon {
+			n = min(n, 2) //nolint:mnd
+		}
+
+		if n >= 2 { //nolint:mnd
+			return colors.XtermPalette.Color256(value(2)), n, true
+		}
+	case 2: //nolint:mnd
+		switch {
+		case colon && n >= 5: //nolint:mnd
+			return colors.Color{R: value(3), G: value(4), B: value(5)}, n, true
+		case n >= 4: //nolint:mnd
+			if !colon {
+				n = 4
+			}
+
+			return colors.Color{R: value(2), G: value(3), B: value(4)}, n, true
+		}
+	}
+
+	if !colon { // unknown color mode - only the mode parameter is consumed
+		n = min(n, 1)
+	}
+
+	return colors.Color{}, n, false
+}
@@ -0,0 +1,162 @@
+// Package colortmpl provides the template functions for styling, e.g. {{ red "x" }}, {{ style "bold green" .Name }}
+// or {{ .Name | bold }}. The text/template functions are built on the colors.TextStyle.Wrap (so the colors state is
+// honored during the template execution), and the html/template ones emit the HTML spans with the inline CSS.
+package colortmpl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"gh.tarampamp.am/colors"
+)
+
+// ErrUnknownStyle is returned when the style name is unknown.
+var ErrUnknownStyle = errors.New("unknown style")
+
+// attrNames maps the text attributes names to the styles.
+var attrNames = map[string]colors.TextStyle{ //nolint:gochecknoglobals // read-only
+	"bold": colors.Bold, "faint": colors.Faint, "italic": colors.Italic, "underline": colors.Underline,
+	"blink": colors.Blinking, "reverse": colors.Reverse, "invisible": colors.Invisible, "strike": colors.Strike,
+}
+
+// colorNames contains the colors names with the foreground and background styles.
+var colorNames = []struct { //nolint:gochecknoglobals // read-only
+	name   string
+	fg, bg colors.TextStyle
+}{
+	{"black", colors.FgBlack, colors.BgBlack}, {"red", colors.FgRed, colors.BgRed},
+	{"green", colors.FgGreen, colors.BgGreen}, {"yellow", colors.FgYellow, colors.BgYellow},
+	{"blue", colors.FgBlue, colors.BgBlue}, {"magenta", colors.FgMagenta, colors.BgMagenta},
+	{"cyan", colors.FgCyan, colors.BgCyan}, {"white", colors.FgWhite, colors.BgWhite},
+}
+
+// ParseStyle parses the style specification - the space (or comma) separated list of the style names, e.g.
+// "bold green", "italic,bright-red" or "black bg-bright-cyan". The names are:
+//
+//   - attributes: bold, faint, italic, underline, blink, reverse, invisible, strike
+//   - colors: black, red, green, yellow, blue, magenta, cyan, white and default
+//   - bright colors: bright-red, bright-green, etc.
+//   - background colors: bg-red, bg-bright-red, bg-default, etc.
+//
+// Empty specification results in the zero style.
+func ParseStyle(spec string) (colors.TextStyle, error) {
+	var style colors.TextStyle
+
+	for _, name := range strings.FieldsFunc(spec, func(r rune) bool { return r == ' ' || r == ',' }) {
+		var s, ok = styleByName(strings.ToLower(name))
+		if !ok {
+			return 0, fmt.Errorf("%w: %q", ErrUnknownStyle, name)
+		}
+
+		style |= s
+	}
+
+	return style, nil
+}
+
+// styleByName returns the style by its name (see ParseStyle).
+func styleByName(name string) (colors.TextStyle, bool) {
+	if s, ok := attrNames[name]; ok {
+		return s, true
+	}
+
+	var bg, bright bool
+
+	if rest, ok := strings.CutPrefix(name, "bg-"); ok {
+		name, bg = rest, true
+	}
+
+	if rest, ok := strings.CutPrefix(name, "bright-"); ok {
+		name, bright = rest, true
+	}
+
+	if name == "default" && !bright {
+		if bg {
+			return colors.BgDefault, true
+		}
+
+		return colors.FgDefault, true
+	}
+
+	for _, c := range colorNames {
+		if c.name != name {
+			continue
+		}
+
+		var style, brightBit = c.fg, colors.FgBright
+
+		if bg {
+			style, brightBit = c.bg, colors.BgBright
+		}
+
+		if bright {
+			style |= brightBit
+		}
+
+		return style, true
+	}
+
+	return 0, false
+}
+
+// funcStyles returns the styles of the single-style functions: the attributes (bold, italic, etc.), the colors (red,
+// brightRed, bgRed and bgBrightRed, etc.).
+func funcStyles() map[string]colors.TextStyle {
+	var styles = make(map[string]colors.TextStyle, len(attrNames)+len(colorNames)*4) //nolint:mnd
+
+	for name, style := range attrNames {
+		styles[name] = style
+	}
+
+	for _, c := range colorNames {
+		var title = strings.ToUpper(c.name[:1]) + c.name[1:]
+
+		styles[c.name] = c.fg
+		styles["bright"+title] = c.fg | colors.FgBright
+		styles["bg"+title] = c.bg
+		styles["bgBright"+title] = c.bg | colors.BgBright
+	}
+
+	return styles
+}
+
+// FuncMap returns the text/template functions:
+//
+//   - bold, italic, red, brightRed, bgRed, bgBrightRed, etc. - wrap the value with the style: {{ red .Name }}
+//   - style - wraps the value with the style specification (see ParseStyle): {{ style "bold green" .Name }}
+//   - width - returns the visible width of the value (see colors.VisibleWidth): {{ width .Name }}
+//   - strip - removes the escape sequences from the value (see colors.StripANSI): {{ strip .Name }}
+//
+// Values of any type are formatted using the fmt.Sprint. The colors state (see colors.Enabled) is checked during
+// the template execution.
+func FuncMap() template.FuncMap { return funcMap(colors.TextStyle.Wrap) }
+
+// FuncMapContext is like FuncMap, but the colors state is taken from the context (see colors.EnabledContext).
+func FuncMapContext(ctx context.Context) template.FuncMap {
+	return funcMap(func(style colors.TextStyle, s string) string { return style.WrapContext(ctx, s) })
+}
+
+// funcMap returns the text/template functions, which use the wrap function for styling.
+func funcMap(wrap func(colors.TextStyle, string) string) template.FuncMap {
+	var m = template.FuncMap{
+		"style": func(spec string, v any) (string, error) {
+			var style, err = ParseStyle(spec)
+			if err != nil {
+				return "", err
+			}
+
+			return wrap(style, fmt.Sprint(v)), nil
+		},
+		"width": func(v any) int { return colors.VisibleWidth(fmt.Sprint(v)) },
+		"strip": func(v any) string { return colors.StripANSI(fmt.Sprint(v)) },
+	}
+
+	for name, style := range funcStyles() {
+		m[name] = func(v any) string { return wrap(style, fmt.Sprint(v)) }
+	}
+
+	return m
+}
@@ -0,0 +1,188 @@
+package colortmpl_test
+
+import (
+	"context"
+	"errors"
+	htmltemplate "html/template"
+	"os"
+	"strings"
+	"testing"
+	"text/template"
+
+	"gh.tarampamp.am/colors"
+	"gh.tarampamp.am/colors/colorstest"
+	"gh.tarampamp.am/colors/colortmpl"
+)
+
+func ExampleFuncMap() {
+	colors.Enabled(false) // change to true to see colors
+
+	var tpl = template.Must(template.New("").Funcs(colortmpl.FuncMap()).Parse(
+		`{{ style "bold green" .Name }} is {{ .Status | red }} ({{ width .Name }} chars)` + "\n",
+	))
+
+	_ = tpl.Execute(os.Stdout, map[string]string{"Name": "api", "Status": "down"})
+
+	// output:
+	// api is down (3 chars)
+}
+
+func ExampleHTMLFuncMap() {
+	var tpl = htmltemplate.Must(htmltemplate.New("").Funcs(colortmpl.HTMLFuncMap()).Parse(
+		`{{ bold (red .) }}` + "\n",
+	))
+
+	_ = tpl.Execute(os.Stdout, "<b>")
+
+	// output:
+	// <span style="font-weight:bold"><span style="color:#cd0000">&lt;b&gt;</span></span>
+}
+
+func TestParseStyle(t *testing.T) {
+	for name, tt := range map[string]struct {
+		give    string
+		want    colors.TextStyle
+		wantErr bool
+	}{
+		"empty":      {give: ""},
+		"attributes": {give: "bold italic,strike", want: colors.Bold | colors.Italic | colors.Strike},
+		"colors":     {give: "Red  bg-blue", want: colors.FgRed | colors.BgBlue},
+		"bright": {
+			give: "bright-cyan bg-bright-white",
+			want: colors.FgCyan | colors.FgBright | colors.BgWhite | colors.BgBright,
+		},
+		"defaults":   {give: "default bg-default", want: colors.FgDefault | colors.BgDefault},
+		"unknown":    {give: "bold purple", wantErr: true},
+		"bright bg":  {give: "bright-bg-red", wantErr: true},
+		"bg attr":    {give: "bg-bold", wantErr: true},
+		"bright def": {give: "bright-default", wantErr: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := colortmpl.ParseStyle(tt.give)
+
+			if tt.wantErr {
+				if !errors.Is(err, colortmpl.ErrUnknownStyle) {
+					t.Fatalf("expected ErrUnknownStyle, got %v", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected %032b, got %032b", tt.want, got)
+			}
+		})
+	}
+}
+
+// execute executes the text template with the functions map.
+func execute(t *testing.T, funcs template.FuncMap, text string, data any) string {
+	t.Helper()
+
+	var buf strings.Builder
+
+	if err := template.Must(template.New("").Funcs(funcs).Parse(text)).Execute(&buf, data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return buf.String()
+}
+
+func TestFuncMap(t *testing.T) {
+	colorstest.Force(t, true)
+
+	for name, tt := range map[string]struct {
+		give string
+		want string
+	}{
+		"color":       {give: `{{ red "x" }}`, want: "[red]x[/]"},
+		"bright":      {give: `{{ brightRed "x" }}`, want: "[bright-red]x[/]"},
+		"background":  {give: `{{ bgBrightBlue 42 }}`, want: "[bg-bright-blue]42[/]"},
+		"attribute":   {give: `{{ . | underline }}`, want: "[underline]data[/]"},
+		"style":       {give: `{{ style "bold, magenta" . }}`, want: "[bold,magenta]data[/]"},
+		"empty style": {give: `{{ style "" . }}`, want: "data"},
+		"width":       {give: `{{ width (red "привет") }}`, want: "6"},
+		"strip":       {give: `{{ strip (red "x") }}`, want: "x"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := colorstest.Markup(execute(t, colortmpl.FuncMap(), tt.give, "data")); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	var err = template.Must(template.New("").Funcs(colortmpl.FuncMap()).Parse(`{{ style "nope" . }}`)).
+		Execute(&strings.Builder{}, nil)
+
+	if !errors.Is(err, colortmpl.ErrUnknownStyle) {
+		t.Errorf("expected ErrUnknownStyle, got %v", err)
+	}
+}
+
+func TestFuncMap_Disabled(t *testing.T) {
+	colorstest.Force(t, false)
+
+	if got := execute(t, colortmpl.FuncMap(), `{{ bold (green .) }}`, "x"); got != "x" {
+		t.Errorf("expected plain text, got %q", got)
+	}
+}
+
+func TestFuncMapContext(t *testing.T) {
+	colorstest.Force(t, false)
+
+	var funcs = colortmpl.FuncMapContext(colors.WithEnabled(context.Background(), true))
+
+	if got := execute(t, funcs, `{{ green . }}`, "x"); got != "\x1b[32mx\x1b[39m" {
+		t.Errorf("expected styled text, got %q", got)
+	}
+}
+
+func TestCSS(t *testing.T) {
+	for name, tt := range map[string]struct {
+		give colors.TextStyle
+		want string
+	}{
+		"zero":     {give: 0, want: ""},
+		"fg":       {give: colors.FgGreen, want: "color:#00cd00"},
+		"bright":   {give: colors.FgBlue | colors.FgBright, want: "color:#5c5cff"},
+		"bg":       {give: colors.BgWhite | colors.BgBright, want: "background-color:#ffffff"},
+		"defaults": {give: colors.FgDefault | colors.BgDefault | colors.Reset, want: ""},
+		"reverse":  {give: colors.Reverse | colors.FgRed, want: "color:Canvas;background-color:#cd0000"},
+		"attrs": {
+			give: colors.Bold | colors.Faint | colors.Italic | colors.Invisible | colors.Underline | colors.Strike |
+				colors.Blinking,
+			want: "font-weight:bold;opacity:0.5;font-style:italic;visibility:hidden;" +
+				"text-decoration:underline line-through blink",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := colortmpl.CSS(tt.give); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestHTMLFuncMap(t *testing.T) {
+	colorstest.Force(t, false) // the colors state does not matter
+
+	var buf strings.Builder
+
+	var tpl = htmltemplate.Must(htmltemplate.New("").Funcs(colortmpl.HTMLFuncMap()).Parse(
+		`{{ style "italic bg-black" .A }}|{{ style "" .A }}|{{ width .B }}|<a title="{{ strip .B }}">`,
+	))
+
+	if err := tpl.Execute(&buf, map[string]string{"A": "a&b", "B": "\x1b[1mb\x1b[0m"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	const want = `<span style="background-color:#000000;font-style:italic">a&amp;b</span>|a&amp;b|1|<a title="b">`
+
+	if got := buf.String(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,133 @@
+package colortmpl
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+
+	"gh.tarampamp.am/colors"
+)
+
+// CSS returns the inline CSS declarations for the style (e.g. "color:#cd0000;font-weight:bold"). The base colors are
+// resolved to RGB using the current palette (see colors.CurrentPalette).
+func CSS(style colors.TextStyle) string {
+	var (
+		fg, bg = paletteColor(style, 0), paletteColor(style, 10) //nolint:mnd // the background bits offset
+		decl   []string
+		decor  []string
+	)
+
+	if style.Has(colors.Reverse) {
+		if fg == "" {
+			fg = "CanvasText"
+		}
+
+		if bg == "" {
+			bg = "Canvas"
+		}
+
+		fg, bg = bg, fg
+	}
+
+	if fg != "" {
+		decl = append(decl, "color:"+fg)
+	}
+
+	if bg != "" {
+		decl = append(decl, "background-color:"+bg)
+	}
+
+	if style.Has(colors.Bold) {
+		decl = append(decl, "font-weight:bold")
+	}
+
+	if style.Has(colors.Faint) {
+		decl = append(decl, "opacity:0.5")
+	}
+
+	if style.Has(colors.Italic) {
+		decl = append(decl, "font-style:italic")
+	}
+
+	if style.Has(colors.Invisible) {
+		decl = append(decl, "visibility:hidden")
+	}
+
+	if style.Has(colors.Underline) {
+		decor = append(decor, "underline")
+	}
+
+	if style.Has(colors.Strike) {
+		decor = append(decor, "line-through")
+	}
+
+	if style.Has(colors.Blinking) {
+		decor = append(decor, "blink")
+	}
+
+	if len(decor) > 0 {
+		decl = append(decl, "text-decoration:"+strings.Join(decor, " "))
+	}
+
+	return strings.Join(decl, ";")
+}
+
+// paletteColor returns the hex RGB color of the foreground (offset 0) or background (offset 10) base color of the
+// style, or an empty string if the color is not set (or it's the default one).
+func paletteColor(style colors.TextStyle, offset int) string {
+	var bits = style >> offset
+
+	for i := range 8 {
+		if bits&(colors.FgBlack<<i) != 0 {
+			if bits&colors.FgBright != 0 {
+				i += 8
+			}
+
+			return colors.CurrentPalette()[i].Hex()
+		}
+	}
+
+	return ""
+}
+
+// htmlSpan returns the value, wrapped into the span with the inline style. The value is escaped, unless it's the
+// template.HTML (e.g. the result of another styling function).
+func htmlSpan(style colors.TextStyle, v any) template.HTML {
+	var content string
+
+	if h, ok := v.(template.HTML); ok {
+		content = string(h)
+	} else {
+		content = template.HTMLEscapeString(fmt.Sprint(v))
+	}
+
+	if css := CSS(style); css != "" {
+		return template.HTML(`<span style="` + css + `">` + content + `</span>`) //nolint:gosec // escaped above
+	}
+
+	return template.HTML(content) //nolint:gosec // escaped above
+}
+
+// HTMLFuncMap returns the html/template functions with the same names as the FuncMap ones. The styling functions
+// emit the HTML spans with the inline CSS (see CSS) regardless of the colors state, and can be nested:
+// {{ bold (red .Name) }}.
+func HTMLFuncMap() template.FuncMap {
+	var m = template.FuncMap{
+		"style": func(spec string, v any) (template.HTML, error) {
+			var style, err = ParseStyle(spec)
+			if err != nil {
+				return "", err
+			}
+
+			return htmlSpan(style, v), nil
+		},
+		"width": func(v any) int { return colors.VisibleWidth(fmt.Sprint(v)) },
+		"strip": func(v any) string { return colors.StripANSI(fmt.Sprint(v)) },
+	}
+
+	for name, style := range funcStyles() {
+		m[name] = func(v any) template.HTML { return htmlSpan(style, v) }
+	}
+
+	return m
+}
@@ -0,0 +1,65 @@
+package colors
+
+import "context"
+
+// enabledCtxKey is a context key for the colors state.
+type enabledCtxKey struct{}
+
+// WithEnabled returns a copy of the parent context that carries the colors state. This state overrides the global one
+// (see Enabled) for the context-aware functions (e.g. TextStyle.WrapContext) only, so different code paths can render
+// styled and plain output concurrently without touching the global state.
+func WithEnabled(ctx context.Context, state bool) context.Context {
+	return context.WithValue(ctx, enabledCtxKey{}, state)
+}
+
+// EnabledContext returns the colors state carried by the context. When the context has no state (see WithEnabled),
+// the global state is returned.
+func EnabledContext(ctx context.Context) bool {
+	if ctx != nil {
+		if state, ok := ctx.Value(enabledCtxKey{}).(bool); ok {
+			return state
+		}
+	}
+
+	return Enabled()
+}
+
+// StartContext is the same as Start, but respects the colors state carried by the context.
+func (ts TextStyle) StartContext(ctx context.Context) string { return ts.StartIf(EnabledContext(ctx)) }
+
+// ResetContext is the same as Reset, but respects the colors state carried by the context.
+func (ts TextStyle) ResetContext(ctx context.Context) string { return ts.ResetIf(EnabledContext(ctx)) }
+
+// WrapContext is the same as Wrap, but respects the colors state carried by the context.
+func (ts TextStyle) WrapContext(ctx context.Context, s string) string {
+	return ts.WrapIf(EnabledContext(ctx), s)
+}
+
+// StartContext is the same as Start, but respects the colors state carried by the context.
+func (cs ColorStyle) StartContext(ctx context.Context) string { return cs.StartIf(EnabledContext(ctx)) }
+
+// ResetContext is the same as Reset, but respects the colors state carried by the context.
+func (cs ColorStyle) ResetContext(ctx context.Context) string { return cs.ResetIf(EnabledContext(ctx)) }
+
+// WrapContext is the same as Wrap, but respects the colors state carried by the context.
+func (cs ColorStyle) WrapContext(ctx context.Context, s string) string {
+	return cs.WrapIf(EnabledContext(ctx), s)
+}
+
+// WrapContext is the same as Wrap, but respects the colors state carried by the context.
+func (g Gradient) WrapContext(ctx context.Context, s string) string {
+	return g.WrapIf(EnabledContext(ctx), s)
+}
+
+// RenderContext is the same as Render, but respects the colors state carried by the context.
+func (t *Table) RenderContext(ctx context.Context) string { return t.RenderIf(EnabledContext(ctx)) }
+
+// RenderContext is the same as Render, but respects the colors state carried by the context.
+func (b *Box) RenderContext(ctx context.Context, content string) string {
+	return b.RenderIf(EnabledContext(ctx), content)
+}
+
+// RenderContext is the same as Render, but respects the colors state carried by the context.
+func (r TreeRenderer) RenderContext(ctx context.Context, root *Tree) string {
+	return r.RenderIf(EnabledContext(ctx), root)
+}
@@ -0,0 +1,137 @@
+package colors_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"gh.tarampamp.am/colors"
+)
+
+func ExampleWithEnabled() {
+	colors.Enabled(true) // the global state
+
+	var ctx = colors.WithEnabled(context.Background(), false) // disable colors for this context only
+
+	fmt.Println(colors.FgRed.WrapContext(ctx, "Foo Bar"))
+
+	// output:
+	// Foo Bar
+}
+
+func TestEnabledContext(t *testing.T) {
+	var colorsState = colors.Enabled()
+
+	defer colors.Enabled(colorsState)
+
+	var (
+		ctx   = context.Background()
+		onCtx = colors.WithEnabled(ctx, true)
+		ofCtx = colors.WithEnabled(onCtx, false) // overrides the parent
+	)
+
+	colors.Enabled(false)
+
+	assertFalse(t, colors.EnabledContext(ctx)) // global
+	assertTrue(t, colors.EnabledContext(onCtx))
+	assertFalse(t, colors.EnabledContext(ofCtx))
+	assertFalse(t, colors.EnabledContext(nil)) //nolint:staticcheck // nil context is allowed
+
+	colors.Enabled(true)
+
+	assertTrue(t, colors.EnabledContext(ctx)) // global
+	assertTrue(t, colors.EnabledContext(onCtx))
+	assertFalse(t, colors.EnabledContext(ofCtx))
+}
+
+func TestTextStyle_Context(t *testing.T) {
+	var colorsState = colors.Enabled()
+
+	defer colors.Enabled(colorsState)
+
+	var (
+		style = colors.FgRed | colors.Bold
+		onCtx = colors.WithEnabled(context.Background(), true)
+		ofCtx = colors.WithEnabled(context.Background(), false)
+	)
+
+	for _, global := range []bool{true, false} {
+		colors.Enabled(global)
+
+		assertEqualValues(t, "\x1b[1;31mFOO\x1b[39;22m", style.WrapContext(onCtx, "FOO"))
+		assertEqualValues(t, "\x1b[1;31m", style.StartContext(onCtx))
+		assertEqualValues(t, "\x1b[39;22m", style.ResetContext(onCtx))
+
+		assertEqualValues(t, "FOO", style.WrapContext(ofCtx, "FOO"))
+		assertEqualValues(t, "", style.StartContext(ofCtx))
+		assertEqualValues(t, "", style.ResetContext(ofCtx))
+	}
+
+	var wg sync.WaitGroup // concurrent usage with different states
+
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+
+		go func(enabled bool) {
+			defer wg.Done()
+
+			var ctx = colors.WithEnabled(context.Background(), enabled)
+
+			if enabled {
+				assertEqualValues(t, "\x1b[1;31mFOO\x1b[39;22m", style.WrapContext(ctx, "FOO"))
+			} else {
+				assertEqualValues(t, "FOO", style.WrapContext(ctx, "FOO"))
+			}
+		}(i%2 == 0)
+	}
+
+	wg.Wait()
+}
+
+func TestTextStyle_WrapIf(t *testing.T) {
+	var style = colors.FgGreen
+
+	assertEqualValues(t, "\x1b[32mFOO\x1b[39m", style.WrapIf(true, "FOO"))
+	assertEqualValues(t, "FOO", style.WrapIf(false, "FOO"))
+	assertEqualValues(t, "\x1b[32m", style.StartIf(true))
+	assertEqualValues(t, "", style.StartIf(false))
+	assertEqualValues(t, "\x1b[39m", style.ResetIf(true))
+	assertEqualValues(t, "", style.ResetIf(false))
+	assertEqualValues(t, "FOO", colors.TextStyle(0).WrapIf(true, "FOO"))
+}
+
+func TestColorStyle_Context(t *testing.T) {
+	var (
+		colorsState = colors.Enabled()
+		profile     = colors.CurrentProfile()
+	)
+
+	defer func() { colors.Enabled(colorsState); colors.CurrentProfile(profile) }()
+
+	colors.CurrentProfile(colors.ProfileTrueColor)
+
+	var (
+		style = colors.Color{R: 1, G: 2, B: 3}.Fg()
+		onCtx = colors.WithEnabled(context.Background(), true)
+		ofCtx = colors.WithEnabled(context.Background(), false)
+	)
+
+	for _, global := range []bool{true, false} {
+		colors.Enabled(global)
+
+		assertEqualValues(t, "\x1b[38;2;1;2;3mFOO\x1b[39m", style.WrapIf(true, "FOO"))
+		assertEqualValues(t, "\x1b[38;2;1;2;3m", style.StartIf(true))
+		assertEqualValues(t, "\x1b[39m", style.ResetIf(true))
+		assertEqualValues(t, "FOO", style.WrapIf(false, "FOO"))
+		assertEqualValues(t, "", style.StartIf(false))
+		assertEqualValues(t, "", style.ResetIf(false))
+
+		assertEqualValues(t, "\x1b[38;2;1;2;3mFOO\x1b[39m", style.WrapContext(onCtx, "FOO"))
+		assertEqualValues(t, "\x1b[38;2;1;2;3m", style.StartContext(onCtx))
+		assertEqualValues(t, "\x1b[39m", style.ResetContext(onCtx))
+		assertEqualValues(t, "FOO", style.WrapContext(ofCtx, "FOO"))
+		assertEqualValues(t, "", style.StartContext(ofCtx))
+		assertEqualValues(t, "", style.ResetContext(ofCtx))
+	}
+}
@@ -0,0 +1,218 @@
+package colors
+
+import "math"
+
+// WCAG 2.x minimal contrast ratios.
+const (
+	WCAGLargeAA float64 = 3   // Large text (18pt, or 14pt bold), level AA
+	WCAGAA      float64 = 4.5 // Normal text, level AA (or large text, level AAA)
+	WCAGAAA     float64 = 7   // Normal text, level AAA
+)
+
+// Luminance returns the relative luminance of the color (0..1), as defined by WCAG 2.x.
+func (c Color) Luminance() float64 {
+	return 0.2126*srgbToLinear(c.R) + 0.7152*srgbToLinear(c.G) + 0.0722*srgbToLinear(c.B) //nolint:mnd
+}
+
+// ContrastRatio returns the WCAG 2.x contrast ratio (1..21) between the foreground and background colors. The
+// result does not depend on the colors order.
+// Docs: <https://www.w3.org/TR/WCAG21/#dfn-contrast-ratio>
+func ContrastRatio(fg, bg Color) float64 {
+	var l1, l2 = fg.Luminance(), bg.Luminance()
+
+	if l1 < l2 {
+		l1, l2 = l2, l1
+	}
+
+	return (l1 + 0.05) / (l2 + 0.05) //nolint:mnd
+}
+
+// APCAContrast returns the APCA (Accessible Perceptual Contrast Algorithm, version 0.0.98G-4g) lightness contrast
+// value Lc (about -108..106) of the text color over the background color. Positive values are for the dark text on a
+// light background, negative values - for the light text on a dark background. The order of colors matters.
+// Docs: <https://github.com/Myndex/apca-w3>
+func APCAContrast(text, bg Color) float64 { //nolint:mnd
+	const (
+		blkThrs, blkClmp               = 0.022, 1.414
+		deltaYMin                      = 0.0005
+		normBG, normTXT, revTXT, revBG = 0.56, 0.57, 0.62, 0.65
+		scale, offset, loClip          = 1.14, 0.027, 0.1
+	)
+
+	var y = func(c Color) float64 {
+		var (
+			r, g, b = c.rgbFloats()
+			v       = 0.2126729*math.Pow(r, 2.4) + 0.7151522*math.Pow(g, 2.4) + 0.0721750*math.Pow(b, 2.4)
+		)
+
+		if v < blkThrs {
+			v += math.Pow(blkThrs-v, blkClmp)
+		}
+
+		return v
+	}
+
+	var yText, yBg = y(text), y(bg)
+
+	if math.Abs(yBg-yText) < deltaYMin {
+		return 0
+	}
+
+	if yBg > yText { // dark text on a light background
+		if sapc := (math.Pow(yBg, normBG) - math.Pow(yText, normTXT)) * scale; sapc >= loClip {
+			return (sapc - offset) * 100
+		}
+
+		return 0
+	}
+
+	if sapc := (math.Pow(yBg, revBG) - math.Pow(yText, revTXT)) * scale; sapc <= -loClip { // light text on dark
+		return (sapc + offset) * 100
+	}
+
+	return 0
+}
+
+// EnsureContrast returns the foreground color, adjusted (lightened or darkened, with the smallest possible change) to
+// reach the minimal WCAG contrast ratio over the background color. The color is returned as is if it already has
+// enough contrast. When the ratio is unreachable, black or white (whichever has higher contrast) is returned.
+func EnsureContrast(fg, bg Color, minRatio float64) Color {
+	if ContrastRatio(fg, bg) >= minRatio {
+		return fg
+	}
+
+	var (
+		best       Color
+		bestWeight = math.Inf(1)
+	)
+
+	for _, target := range [...]Color{{255, 255, 255}, {}} {
+		if ContrastRatio(target, bg) < minRatio {
+			continue // unreachable in this direction
+		}
+
+		var lo, hi = 0.0, 1.0 // binary search for the smallest mixing weight
+
+		for i := 0; i < 24; i++ {
+			if mid := (lo + hi) / 2; ContrastRatio(fg.Mix(target, mid), bg) >= minRatio { //nolint:mnd
+				hi = mid
+			} else {
+				lo = mid
+			}
+		}
+
+		if hi < bestWeight {
+			best, bestWeight = fg.Mix(target, hi), hi
+		}
+	}
+
+	if math.IsInf(bestWeight, 1) {
+		if white, black := (Color{255, 255, 255}), (Color{}); ContrastRatio(white, bg) >= ContrastRatio(black, bg) {
+			return white
+		}
+
+		return Color{}
+	}
+
+	return best
+}
+
+// Fg resolves the style foreground color (e.g. FgRed|FgBright) to RGB. False is returned when the style has no
+// foreground color, or it is FgDefault.
+func (p Palette) Fg(style TextStyle) (Color, bool) {
+	return p.resolve(style, FgBlack, FgDefault, FgBright)
+}
+
+// Bg resolves the style background color (e.g. BgRed|BgBright) to RGB. False is returned when the style has no
+// background color, or it is BgDefault.
+func (p Palette) Bg(style TextStyle) (Color, bool) {
+	return p.resolve(style, BgBlack, BgDefault, BgBright)
+}
+
+// resolve resolves the style color to RGB (see Fg and Bg).
+func (p Palette) resolve(style, first, def, bright TextStyle) (Color, bool) {
+	if style.Has(def) {
+		return Color{}, false
+	}
+
+	for i := 0; i < 8; i++ { // the same precedence as for the color codes rendering
+		if style.Has(first << i) {
+			if style.Has(bright) {
+				return p[i+8], true //nolint:mnd
+			}
+
+			return p[i], true
+		}
+	}
+
+	return Color{}, false
+}
+
+// ContrastRatio returns the WCAG 2.x contrast ratio between the foreground and background colors of the style (e.g.
+// FgBlue|BgBlack). False is returned when any of the colors cannot be resolved (see Fg and Bg).
+func (p Palette) ContrastRatio(style TextStyle) (float64, bool) {
+	fg, bg, ok := p.pair(style)
+	if !ok {
+		return 0, false
+	}
+
+	return ContrastRatio(fg, bg), true
+}
+
+// APCAContrast returns the APCA Lc value of the foreground over the background color of the style (e.g.
+// FgBlue|BgBlack). False is returned when any of the colors cannot be resolved (see Fg and Bg).
+func (p Palette) APCAContrast(style TextStyle) (float64, bool) {
+	fg, bg, ok := p.pair(style)
+	if !ok {
+		return 0, false
+	}
+
+	return APCAContrast(fg, bg), true
+}
+
+// pair resolves the foreground and background colors of the style.
+func (p Palette) pair(style TextStyle) (fg, bg Color, _ bool) {
+	var fgOk, bgOk bool
+
+	fg, fgOk = p.Fg(style)
+	bg, bgOk = p.Bg(style)
+
+	return fg, bg, fgOk && bgOk
+}
+
+// ReadableFg returns the style with the foreground color replaced by the base color, that reaches the minimal WCAG
+// contrast ratio over the style background, and is perceptually nearest to the original foreground color. The style
+// is returned as is when it already has enough contrast, or any of the colors cannot be resolved. When no base color
+// reaches the ratio, the one with the highest contrast is used.
+func (p Palette) ReadableFg(style TextStyle, minRatio float64) TextStyle {
+	fg, bg, ok := p.pair(style)
+	if !ok || ContrastRatio(fg, bg) >= minRatio {
+		return style
+	}
+
+	var (
+		fgLab                  = fg.OKLab()
+		bestIdx, fallbackIdx   = -1, 0
+		bestDist, bestFallback = math.Inf(1), 0.0
+	)
+
+	for i, c := range p {
+		var ratio = ContrastRatio(c, bg)
+
+		if ratio > bestFallback {
+			bestFallback, fallbackIdx = ratio, i
+		}
+
+		if ratio >= minRatio {
+			if dist := okLabDistance(fgLab, c.OKLab()); dist < bestDist {
+				bestDist, bestIdx = dist, i
+			}
+		}
+	}
+
+	if bestIdx < 0 {
+		bestIdx = fallbackIdx
+	}
+
+	return style&^FgMask | paletteStyle(bestIdx, FgBlack, FgBright)
+}
@@ -0,0 +1,129 @@
+package colors_test
+
+import (
+	"fmt"
+	"testing"
+
+	"gh.tarampamp.am/colors"
+)
+
+func ExamplePalette_ContrastRatio() {
+	var ratio, _ = colors.XtermPalette.ContrastRatio(colors.FgBlue | colors.BgBlack)
+
+	fmt.Printf("%.2f %t\n", ratio, ratio >= colors.WCAGAA)
+
+	// output:
+	// 2.23 false
+}
+
+func TestContrastRatio(t *testing.T) {
+	var black, white = colors.Color{}, colors.Color{R: 255, G: 255, B: 255}
+
+	assertNear(t, 21, colors.ContrastRatio(black, white))
+	assertNear(t, 21, colors.ContrastRatio(white, black))
+	assertNear(t, 1, colors.ContrastRatio(white, white))
+	assertNear(t, 3.5449, colors.ContrastRatio(colors.Color{R: 136, G: 136, B: 136}, white))
+	assertNear(t, 0, black.Luminance())
+	assertNear(t, 1, white.Luminance())
+}
+
+func TestAPCAContrast(t *testing.T) {
+	var (
+		black, white = colors.Color{}, colors.Color{R: 255, G: 255, B: 255}
+		gray         = colors.Color{R: 136, G: 136, B: 136}
+	)
+
+	assertNear(t, 106.0403, colors.APCAContrast(black, white))
+	assertNear(t, -107.8847, colors.APCAContrast(white, black))
+	assertNear(t, 63.0561, colors.APCAContrast(gray, white))
+	assertNear(t, -68.5415, colors.APCAContrast(white, gray))
+	assertNear(t, 0, colors.APCAContrast(gray, gray))
+	assertNear(t, 0, colors.APCAContrast(colors.Color{R: 10, G: 10, B: 10}, black)) // below the low clip
+}
+
+func TestEnsureContrast(t *testing.T) {
+	var (
+		black, white = colors.Color{}, colors.Color{R: 255, G: 255, B: 255}
+		blue         = colors.Color{B: 238}
+	)
+
+	assertEqualValues(t, white, colors.EnsureContrast(white, black, colors.WCAGAA)) // already enough
+
+	for _, tt := range []struct {
+		fg, bg colors.Color
+		ratio  float64
+	}{
+		{blue, black, colors.WCAGAA},
+		{blue, black, colors.WCAGAAA},
+		{colors.Color{R: 200, G: 200}, white, colors.WCAGAA},
+		{colors.Color{R: 128, G: 128, B: 128}, colors.Color{R: 120, G: 120, B: 120}, colors.WCAGLargeAA},
+	} {
+		var got = colors.EnsureContrast(tt.fg, tt.bg, tt.ratio)
+
+		assertTrue(t, colors.ContrastRatio(got, tt.bg) >= tt.ratio)
+		assertTrue(t, got != black && got != white)
+	}
+
+	// unreachable ratio - the highest contrast extreme
+	assertEqualValues(t, white, colors.EnsureContrast(blue, black, 25))
+	assertEqualValues(t, black, colors.EnsureContrast(blue, white, 25))
+}
+
+func TestPalette_FgBg(t *testing.T) {
+	var p = colors.XtermPalette
+
+	c, ok := p.Fg(colors.FgRed | colors.BgBlue)
+	assertTrue(t, ok)
+	assertEqualValues(t, p[1], c)
+
+	c, ok = p.Fg(colors.FgRed | colors.FgBright)
+	assertTrue(t, ok)
+	assertEqualValues(t, p[9], c)
+
+	c, ok = p.Bg(colors.FgRed | colors.BgBlue | colors.BgBright)
+	assertTrue(t, ok)
+	assertEqualValues(t, p[12], c)
+
+	_, ok = p.Fg(colors.FgDefault)
+	assertFalse(t, ok)
+
+	_, ok = p.Bg(colors.FgRed)
+	assertFalse(t, ok)
+
+	_, ok = p.ContrastRatio(colors.FgRed)
+	assertFalse(t, ok)
+
+	_, ok = p.APCAContrast(colors.BgRed)
+	assertFalse(t, ok)
+
+	lc, ok := p.APCAContrast(colors.FgBlack | colors.BgWhite | colors.BgBright)
+	assertTrue(t, ok)
+	assertNear(t, 106.0403, lc)
+}
+
+func TestPalette_ReadableFg(t *testing.T) {
+	var p = colors.XtermPalette
+
+	for _, style := range []colors.TextStyle{
+		colors.FgBlue | colors.BgBlack,
+		colors.FgBlue | colors.BgBlack | colors.Bold,
+		colors.FgYellow | colors.FgBright | colors.BgWhite | colors.BgBright,
+		colors.FgRed | colors.BgRed,
+	} {
+		var got = p.ReadableFg(style, colors.WCAGAA)
+
+		ratio, ok := p.ContrastRatio(got)
+
+		assertTrue(t, ok)
+		assertTrue(t, ratio >= colors.WCAGAA)
+		assertEqualValues(t, style.Has(colors.Bold), got.Has(colors.Bold))
+		assertEqualValues(t, style&^0x3ff, got&^0x3ff) // only the foreground bits are changed
+	}
+
+	// already readable or unresolvable styles are returned as is
+	assertEqualValues(t, colors.FgWhite|colors.BgBlack, p.ReadableFg(colors.FgWhite|colors.BgBlack, colors.WCAGAA))
+	assertEqualValues(t, colors.FgBlue, p.ReadableFg(colors.FgBlue, colors.WCAGAA))
+
+	// unreachable ratio - the highest contrast color
+	assertEqualValues(t, colors.FgWhite|colors.FgBright|colors.BgBlack, p.ReadableFg(colors.FgBlue|colors.BgBlack, 25))
+}
@@ -0,0 +1,340 @@
+// Package diag provides the compiler-style (rustc-like) diagnostics rendering: the colored severity header, the
+// location (file:line:col), the source code excerpt with the caret/underline spans under the offending ranges, and
+// the labeled notes. The output falls back to plain text when colors are disabled (see colors.Enabled, or
+// Renderer.RenderTo to detect colors for the specific writer).
+package diag
+
+import (
+	"context"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gh.tarampamp.am/colors"
+	"gh.tarampamp.am/colors/highlight"
+)
+
+// Severity is a diagnostic severity.
+type Severity uint8
+
+const (
+	Error   Severity = iota // error
+	Warning                 // warning
+	Note                    // note
+	Help                    // help
+)
+
+// String returns the severity name.
+func (s Severity) String() string {
+	switch s {
+	case Error:
+		return "error"
+	case Warning:
+		return "warning"
+	case Note:
+		return "note"
+	case Help:
+		return "help"
+	}
+
+	return "severity(" + strconv.Itoa(int(s)) + ")"
+}
+
+// Label is a labeled source code span. Lines and columns are 1-based, columns are measured in bytes (as the go/token
+// package does). Spans can not cross the line boundary.
+type Label struct {
+	Line, Col int    // Span start position
+	EndCol    int    // Span end column (exclusive), a single column span if zero
+	Message   string // Label message, rendered after the underline (optional)
+	Primary   bool   // Primary labels are underlined with carets ("^"), secondary ones with dashes ("-")
+}
+
+// Diagnostic is a single diagnostic message.
+//
+//	var d = diag.Diagnostic{
+//		Severity: diag.Error,
+//		Message:  "mismatched types",
+//		File:     "main.go",
+//		Source:   src,
+//		Labels:   []diag.Label{{Line: 3, Col: 14, EndCol: 19, Message: "expected int", Primary: true}},
+//		Notes:    []string{"untyped string constant can not be used as int"},
+//	}
+//
+//	_ = diag.DefaultRenderer.RenderTo(os.Stderr, d) // colors are detected for the stderr
+type Diagnostic struct {
+	Severity Severity
+	Code     string   // Diagnostic code (e.g. "E0308"), rendered in the header (optional)
+	Message  string   // Diagnostic message
+	File     string   // Source file name (optional)
+	Source   string   // Source code (the whole file), the excerpt is not rendered if empty
+	Labels   []Label  // Labeled spans, the location is taken from the first primary (or just the first) label
+	Notes    []string // Notes, rendered after the excerpt ("= note: ...")
+	Help     []string // Help messages, rendered after the notes ("= help: ...")
+}
+
+// String returns the diagnostic, rendered using the default renderer.
+func (d Diagnostic) String() string { return DefaultRenderer.Render(d) }
+
+// location returns the primary label (the first primary one, or just the first one).
+func (d Diagnostic) location() (Label, bool) {
+	for _, l := range d.Labels {
+		if l.Primary {
+			return l, true
+		}
+	}
+
+	if len(d.Labels) > 0 {
+		return d.Labels[0], true
+	}
+
+	return Label{}, false
+}
+
+// Theme is a set of the diagnostics styles.
+type Theme struct {
+	Error, Warning, Note, Help colors.TextStyle // Severity styles (the header, notes and primary labels)
+
+	Message   colors.TextStyle // Header message style
+	Gutter    colors.TextStyle // Line numbers, gutter and location arrow style
+	Secondary colors.TextStyle // Secondary labels style
+	Syntax    highlight.Theme  // Source code syntax highlighting theme (Go), plain source code if zero
+}
+
+// severity returns the severity style.
+func (t Theme) severity(s Severity) colors.TextStyle {
+	switch s {
+	case Error:
+		return t.Error
+	case Warning:
+		return t.Warning
+	case Note:
+		return t.Note
+	case Help:
+		return t.Help
+	}
+
+	return 0
+}
+
+// DefaultTheme is the default (rustc-like) theme.
+var DefaultTheme = Theme{ //nolint:gochecknoglobals
+	Error:     colors.FgRed | colors.FgBright | colors.Bold,
+	Warning:   colors.FgYellow | colors.FgBright | colors.Bold,
+	Note:      colors.FgGreen | colors.FgBright | colors.Bold,
+	Help:      colors.FgCyan | colors.FgBright | colors.Bold,
+	Message:   colors.Bold,
+	Gutter:    colors.FgBlue | colors.FgBright | colors.Bold,
+	Secondary: colors.FgBlue | colors.FgBright | colors.Bold,
+}
+
+// Renderer renders diagnostics.
+type Renderer struct {
+	Theme   Theme // Styles
+	Context int   // Number of the source lines to render before and after the labeled lines
+	TabSize int   // Number of spaces to replace tabs with in the source lines, 4 by default
+}
+
+// DefaultRenderer is the renderer, used by the Diagnostic.String.
+var DefaultRenderer = Renderer{Theme: DefaultTheme} //nolint:gochecknoglobals
+
+// Render returns the rendered diagnostic. The result ends with a line break.
+func (r Renderer) Render(d Diagnostic) string { return r.RenderIf(colors.Enabled(), d) }
+
+// RenderContext is the same as Render, but respects the colors state carried by the context (see
+// colors.WithEnabled).
+func (r Renderer) RenderContext(ctx context.Context, d Diagnostic) string {
+	return r.RenderIf(colors.EnabledContext(ctx), d)
+}
+
+// RenderTo writes the rendered diagnostic into the writer. Colors are detected for the writer itself (see
+// colors.ColorModeAuto), not for the os.Stdout as the global colors state is.
+func (r Renderer) RenderTo(w io.Writer, d Diagnostic) error {
+	_, err := io.WriteString(w, r.RenderIf(colors.ColorModeAuto.Enabled(w), d))
+
+	return err
+}
+
+// RenderIf is the same as Render, but uses the provided colors state instead of the global one.
+func (r Renderer) RenderIf(enabled bool, d Diagnostic) string {
+	var (
+		buf         strings.Builder
+		sevStyle    = r.Theme.severity(d.Severity)
+		lines       = r.excerptLines(d)
+		loc, hasLoc = d.location()
+		numWidth    = 1
+	)
+
+	if len(lines) > 0 {
+		numWidth = len(strconv.Itoa(lines[len(lines)-1]))
+	}
+
+	// header: error[E0308]: message
+	var header = d.Severity.String()
+
+	if d.Code != "" {
+		header += "[" + d.Code + "]"
+	}
+
+	buf.WriteString(sevStyle.WrapIf(enabled, header))
+	buf.WriteString(r.Theme.Message.WrapIf(enabled, ": "+d.Message))
+	buf.WriteByte('\n')
+
+	// location: --> file:line:col
+	if where := location(d.File, loc, hasLoc); where != "" {
+		buf.WriteString(strings.Repeat(" ", numWidth))
+		buf.WriteString(r.Theme.Gutter.WrapIf(enabled, "-->"))
+		buf.WriteString(" " + where + "\n")
+	}
+
+	var gutter = r.Theme.Gutter.WrapIf(enabled, strings.Repeat(" ", numWidth+1)+"|")
+
+	if len(lines) > 0 {
+		buf.WriteString(gutter + "\n")
+
+		r.writeExcerpt(&buf, d, lines, numWidth, enabled)
+	}
+
+	if len(d.Notes)+len(d.Help) > 0 {
+		if len(lines) > 0 {
+			buf.WriteString(gutter + "\n")
+		}
+
+		for _, note := range d.Notes {
+			r.writeNote(&buf, Note, note, numWidth, enabled)
+		}
+
+		for _, help := range d.Help {
+			r.writeNote(&buf, Help, help, numWidth, enabled)
+		}
+	}
+
+	return buf.String()
+}
+
+// location returns the location string (file:line:col), empty if nothing is known.
+func location(file string, l Label, ok bool) string {
+	if !ok {
+		return file
+	}
+
+	var where = strconv.Itoa(l.Line) + ":" + strconv.Itoa(max(l.Col, 1))
+
+	if file != "" {
+		return file + ":" + where
+	}
+
+	return where
+}
+
+// excerptLines returns the sorted numbers of the source lines to render (the labeled lines with the context).
+func (r Renderer) excerptLines(d Diagnostic) []int {
+	if d.Source == "" {
+		return nil
+	}
+
+	var (
+		total = strings.Count(strings.TrimSuffix(d.Source, "\n"), "\n") + 1
+		lines []int
+	)
+
+	for _, l := range d.Labels {
+		for n := l.Line - max(r.Context, 0); n <= l.Line+max(r.Context, 0); n++ {
+			if n >= 1 && n <= total && !slices.Contains(lines, n) {
+				lines = append(lines, n)
+			}
+		}
+	}
+
+	slices.Sort(lines)
+
+	return lines
+}
+
+// writeExcerpt writes the source lines with the labels underlines.
+func (r Renderer) writeExcerpt(buf *strings.Builder, d Diagnostic, lines []int, numWidth int, enabled bool) {
+	var (
+		source = strings.SplitAfter(d.Source, "\n")
+		styled = strings.SplitAfter(highlight.Highlighter{
+			Theme: r.Theme.Syntax,
+			Lines: [2]int{lines[0], lines[len(lines)-1]},
+		}.HighlightIf(enabled, d.Source), "\n")
+		gutter = r.Theme.Gutter.WrapIf(enabled, strings.Repeat(" ", numWidth+1)+"|")
+		tab    = strings.Repeat(" ", r.tabSize())
+	)
+
+	for i, n := range lines {
+		if i > 0 && n > lines[i-1]+1 {
+			buf.WriteString(r.Theme.Gutter.WrapIf(enabled, "...") + "\n")
+		}
+
+		var (
+			num  = strconv.Itoa(n)
+			text = strings.TrimRight(source[n-1], "\r\n")
+			line = strings.TrimSuffix(styled[n-lines[0]], "\n")
+		)
+
+		buf.WriteString(r.Theme.Gutter.WrapIf(enabled, strings.Repeat(" ", numWidth-len(num))+num+" |"))
+
+		if line != "" {
+			buf.WriteString(" " + strings.ReplaceAll(line, "\t", tab))
+		}
+
+		buf.WriteByte('\n')
+
+		for _, l := range r.lineLabels(d.Labels, n) {
+			var (
+				from  = min(max(l.Col, 1)-1, len(text))
+				to    = min(max(l.EndCol-1, from+1), len(text))
+				style = r.Theme.Secondary
+				mark  = "-"
+				pad   = colors.VisibleWidth(strings.ReplaceAll(text[:from], "\t", tab))
+				width = max(colors.VisibleWidth(strings.ReplaceAll(text[from:to], "\t", tab)), 1)
+			)
+
+			if l.Primary {
+				style, mark = r.Theme.severity(d.Severity), "^"
+			}
+
+			var underline = strings.Repeat(mark, width)
+
+			if l.Message != "" {
+				underline += " " + l.Message
+			}
+
+			buf.WriteString(gutter + " " + strings.Repeat(" ", pad) + style.WrapIf(enabled, underline) + "\n")
+		}
+	}
+}
+
+// lineLabels returns the labels of the line, sorted by the column (the rightmost first, as the rightmost label
+// underline does not overlap the other ones).
+func (Renderer) lineLabels(labels []Label, line int) []Label {
+	var result []Label
+
+	for _, l := range labels {
+		if l.Line == line {
+			result = append(result, l)
+		}
+	}
+
+	slices.SortStableFunc(result, func(a, b Label) int { return b.Col - a.Col })
+
+	return result
+}
+
+// writeNote writes the note (or help) line.
+func (r Renderer) writeNote(buf *strings.Builder, s Severity, message string, numWidth int, enabled bool) {
+	buf.WriteString(strings.Repeat(" ", numWidth+1))
+	buf.WriteString(r.Theme.Gutter.WrapIf(enabled, "="))
+	buf.WriteString(" " + r.Theme.severity(s).WrapIf(enabled, s.String()+":") + " " + message + "\n")
+}
+
+// tabSize returns the number of spaces to replace tabs with.
+func (r Renderer) tabSize() int {
+	if r.TabSize > 0 {
+		return r.TabSize
+	}
+
+	return 4 //nolint:mnd
+}
@@ -0,0 +1,219 @@
+package diag_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"gh.tarampamp.am/colors"
+	"gh.tarampamp.am/colors/colorstest"
+	"gh.tarampamp.am/colors/diag"
+)
+
+const src = "package main\n\nfunc main() {\n\tvar x int = \"foo\"\n\n\t_ = x + y\n}\n"
+
+func ExampleDiagnostic() {
+	colors.Enabled(false) // change to true to see colors
+
+	fmt.Print(diag.Diagnostic{
+		Severity: diag.Error,
+		Code:     "E0308",
+		Message:  "mismatched types",
+		File:     "main.go",
+		Source:   src,
+		Labels: []diag.Label{
+			{Line: 4, Col: 14, EndCol: 19, Message: "expected int, found string", Primary: true},
+			{Line: 4, Col: 8, EndCol: 11, Message: "expected due to this type"},
+		},
+		Notes: []string{"untyped string constant can not be used as int"},
+	})
+
+	// output:
+	// error[E0308]: mismatched types
+	//  --> main.go:4:14
+	//   |
+	// 4 |     var x int = "foo"
+	//   |                 ^^^^^ expected int, found string
+	//   |           --- expected due to this type
+	//   |
+	//   = note: untyped string constant can not be used as int
+}
+
+func TestRenderer_Render(t *testing.T) {
+	colorstest.Force(t, false)
+
+	for name, tt := range map[string]struct {
+		renderer diag.Renderer
+		give     diag.Diagnostic
+		want     string
+	}{
+		"message only": {
+			give: diag.Diagnostic{Severity: diag.Warning, Message: "something is wrong"},
+			want: "warning: something is wrong\n",
+		},
+		"file only": {
+			give: diag.Diagnostic{Message: "no such file", File: "main.go", Help: []string{"check the path"}},
+			want: "error: no such file\n --> main.go\n  = help: check the path\n",
+		},
+		"no source": {
+			give: diag.Diagnostic{Severity: diag.Note, Message: "x", Labels: []diag.Label{{Line: 12, Col: 3}}},
+			want: "note: x\n --> 12:3\n",
+		},
+		"single column": {
+			give: diag.Diagnostic{
+				Message: "undefined: y",
+				Source:  src,
+				Labels:  []diag.Label{{Line: 6, Col: 10, Primary: true}},
+			},
+			want: "error: undefined: y\n --> 6:10\n  |\n6 |     _ = x + y\n  |             ^\n",
+		},
+		"context and gaps": {
+			renderer: diag.Renderer{Context: 1, TabSize: 2},
+			give: diag.Diagnostic{
+				Message: "unused",
+				File:    "a.go",
+				Source:  src,
+				Labels:  []diag.Label{{Line: 1, Col: 9, EndCol: 13, Message: "here"}, {Line: 6, Col: 6, EndCol: 7}},
+				Notes:   []string{"a", "b"},
+			},
+			want: "error: unused\n" +
+				" --> a.go:1:9\n" +
+				"  |\n" +
+				"1 | package main\n" +
+				"  |         ---- here\n" +
+				"2 |\n" +
+				"...\n" +
+				"5 |\n" +
+				"6 |   _ = x + y\n" +
+				"  |       -\n" +
+				"7 | }\n" +
+				"  |\n" +
+				"  = note: a\n" +
+				"  = note: b\n",
+		},
+		"wide line numbers": {
+			give: diag.Diagnostic{
+				Message: "x",
+				Source:  "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\n",
+				Labels:  []diag.Label{{Line: 10, Col: 1, Primary: true}, {Line: 9, Col: 5}},
+			},
+			want: "error: x\n  --> 10:1\n   |\n 9 | i\n   |  -\n10 | j\n   | ^\n",
+		},
+		"out of range": {
+			give: diag.Diagnostic{Message: "x", Source: "abc\n", Labels: []diag.Label{{Line: 2, Col: 1}}},
+			want: "error: x\n --> 2:1\n",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.renderer.Render(tt.give); got != tt.want {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRenderer_Render_Styled(t *testing.T) {
+	colorstest.Force(t, true)
+
+	var got = colorstest.Markup(diag.Diagnostic{
+		Severity: diag.Warning,
+		Message:  "unused variable",
+		Source:   src,
+		Labels:   []diag.Label{{Line: 4, Col: 6, EndCol: 7, Primary: true}},
+		Help:     []string{"remove it"},
+	}.String())
+
+	const want = "[bold,bright-yellow]warning[/][bold]: unused variable[/]\n" +
+		" [bold,bright-blue]-->[/] 4:6\n" +
+		"[bold,bright-blue]  |[/]\n" +
+		"[bold,bright-blue]4 |[/]     var x int = \"foo\"\n" +
+		"[bold,bright-blue]  |[/]         [bold,bright-yellow]^[/]\n" +
+		"[bold,bright-blue]  |[/]\n" +
+		"  [bold,bright-blue]=[/] [bold,bright-cyan]help:[/] remove it\n"
+
+	if got != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+
+	var renderer = diag.Renderer{Theme: diag.DefaultTheme}
+
+	renderer.Theme.Syntax.Keyword = colors.Bold
+
+	got = colorstest.Markup(renderer.Render(diag.Diagnostic{
+		Message: "x",
+		Source:  src,
+		Labels:  []diag.Label{{Line: 3, Col: 1, EndCol: 5}},
+	}))
+
+	const wantSyntax = "[bold,bright-red]error[/][bold]: x[/]\n" +
+		" [bold,bright-blue]-->[/] 3:1\n" +
+		"[bold,bright-blue]  |[/]\n" +
+		"[bold,bright-blue]3 |[/] [bold]func[/] main() {\n" +
+		"[bold,bright-blue]  |[/] [bold,bright-blue]----[/]\n"
+
+	if got != wantSyntax {
+		t.Errorf("expected:\n%s\ngot:\n%s", wantSyntax, got)
+	}
+}
+
+func TestRenderer_RenderIf(t *testing.T) {
+	var (
+		renderer = diag.Renderer{Theme: diag.DefaultTheme}
+		d        = diag.Diagnostic{Message: "x", Source: src, Labels: []diag.Label{{Line: 3, Col: 1, EndCol: 5}}}
+	)
+
+	renderer.Theme.Syntax.Keyword = colors.Bold
+
+	const (
+		plain = "error: x\n" +
+			" --> 3:1\n" +
+			"  |\n" +
+			"3 | func main() {\n" +
+			"  | ----\n"
+		styled = "[bold,bright-red]error[/][bold]: x[/]\n" +
+			" [bold,bright-blue]-->[/] 3:1\n" +
+			"[bold,bright-blue]  |[/]\n" +
+			"[bold,bright-blue]3 |[/] [bold]func[/] main() {\n" +
+			"[bold,bright-blue]  |[/] [bold,bright-blue]----[/]\n"
+	)
+
+	for _, global := range []bool{true, false} { // the global colors state does not matter
+		colorstest.Force(t, global)
+
+		for _, tt := range []struct {
+			got, want string
+		}{
+			{renderer.RenderIf(true, d), styled},
+			{renderer.RenderIf(false, d), plain},
+			{renderer.RenderContext(colors.WithEnabled(context.Background(), true), d), styled},
+			{renderer.RenderContext(colors.WithEnabled(context.Background(), false), d), plain},
+		} {
+			if got := colorstest.Markup(tt.got); got != tt.want {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.want, got)
+			}
+		}
+	}
+}
+
+func TestRenderer_RenderTo(t *testing.T) {
+	colorstest.Force(t, true)
+
+	t.Setenv("FORCE_COLOR", "")
+	_ = os.Unsetenv("FORCE_COLOR") // restored by the t.Setenv cleanup
+
+	var (
+		buf bytes.Buffer
+		d   = diag.Diagnostic{Message: "x", Notes: []string{"y"}}
+	)
+
+	if err := diag.DefaultRenderer.RenderTo(&buf, d); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// not a terminal, so no colors despite the global state
+	if want := "error: x\n  = note: y\n"; buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+}
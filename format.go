@@ -0,0 +1,98 @@
+package colors
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// StyledValue is a value with the text style, that implements the fmt.Formatter. The width and flags are applied to
+// the visible text (see VisibleWidth), not to the escape sequences, so the styled values can be aligned using the
+// fmt verbs (e.g. "%-20s"). Use the Styled function to create it.
+type StyledValue struct {
+	Value any       // Value to format
+	Style TextStyle // Style of the formatted value
+}
+
+// Styled returns the value with the text style, that implements the fmt.Formatter:
+//
+//	fmt.Printf("%-10v|\n", colors.Styled(err, colors.FgRed)) // the padding is not styled
+//
+// The value is formatted using the verb, flags and precision (the precision of the %s and %v verbs limits the
+// visible width of the text, see Truncate, except for the numbers formatted with %v), then padded to the width with
+// spaces, and the text (without the padding) is wrapped with the style start and reset sequences (if colors are
+// enabled, see Enabled). The zero padding is applied by the fmt package itself (e.g. "%08.3f").
+func Styled(v any, style TextStyle) StyledValue { return StyledValue{Value: v, Style: style} }
+
+// String returns the styled value, formatted using the %v verb.
+func (sv StyledValue) String() string { return sv.Style.Wrap(fmt.Sprint(sv.Value)) }
+
+// Format implements the fmt.Formatter interface.
+func (sv StyledValue) Format(f fmt.State, verb rune) {
+	var (
+		format         strings.Builder
+		width, hasW    = f.Width()
+		prec, hasPrec  = f.Precision()
+		zero, left     = f.Flag('0'), f.Flag('-')
+		truncate       = hasPrec && (verb == 's' || verb == 'v' && !isNumber(sv.Value))
+		delegatedWidth = hasW && zero && !left // zero padding is done by the fmt package
+	)
+
+	format.WriteByte('%')
+
+	for _, flag := range "+# 0" {
+		if f.Flag(int(flag)) {
+			format.WriteRune(flag)
+		}
+	}
+
+	if delegatedWidth {
+		format.WriteString(strconv.Itoa(width))
+	}
+
+	if hasPrec && !truncate {
+		format.WriteByte('.')
+		format.WriteString(strconv.Itoa(prec))
+	}
+
+	format.WriteRune(verb)
+
+	var text = fmt.Sprintf(format.String(), sv.Value)
+
+	if truncate {
+		text = Truncate(text, prec, "")
+	}
+
+	var pad string
+
+	if hasW && !delegatedWidth {
+		pad = strings.Repeat(" ", max(width-VisibleWidth(text), 0))
+	}
+
+	if !left {
+		_, _ = f.Write([]byte(pad))
+	}
+
+	_, _ = f.Write([]byte(sv.Style.Wrap(text)))
+
+	if left {
+		_, _ = f.Write([]byte(pad))
+	}
+}
+
+// isNumber returns true if the value is a number (the precision has a special meaning for numbers).
+func isNumber(v any) bool {
+	if v == nil {
+		return false
+	}
+
+	switch reflect.TypeOf(v).Kind() { //nolint:exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	}
+
+	return false
+}
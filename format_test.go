@@ -0,0 +1,73 @@
+package colors_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"gh.tarampamp.am/colors"
+)
+
+func ExampleStyled() {
+	colors.Enabled(false) // change to true to see colors
+
+	fmt.Printf("[%-8v] %6.2f%%\n", colors.Styled(errors.New("failed"), colors.FgRed), colors.Styled(99.5, colors.Bold))
+
+	// output:
+	// [failed  ]  99.50%
+}
+
+func TestStyled(t *testing.T) {
+	var colorsState = colors.Enabled()
+
+	defer colors.Enabled(colorsState)
+
+	colors.Enabled(true)
+
+	var red = func(s string) string { return "\x1b[31m" + s + "\x1b[39m" }
+
+	for name, tt := range map[string]struct {
+		giveFormat string
+		giveValue  any
+		want       string
+	}{
+		"plain":          {"%v", "foo", red("foo")},
+		"right aligned":  {"%6s|", "foo", "   " + red("foo") + "|"},
+		"left aligned":   {"%-6s|", "foo", red("foo") + "   |"},
+		"wide chars":     {"%-6s|", "日本", red("日本") + "  |"},
+		"styled value":   {"%5v|", colors.Bold.Wrap("ab"), "   " + red("\x1b[1mab\x1b[22m") + "|"},
+		"too wide":       {"%2s", "foobar", red("foobar")},
+		"precision":      {"%-5.3s|", "日本語", red("日") + "   |"},
+		"styled prec":    {"%.1v", colors.Bold.Wrap("ab"), red("\x1b[1ma\x1b[22m")},
+		"float":          {"%8.3f", 3.14159, "   " + red("3.142")},
+		"float v prec":   {"%.2v", 3.14159, red("3.1")},
+		"int precision":  {"%.3v", 7, red("007")},
+		"zero padding":   {"%06d", -42, red("-00042")},
+		"zero left":      {"%-06d|", 42, red("42") + "    |"},
+		"plus flag":      {"%+5d", 42, "  " + red("+42")},
+		"sharp flag":     {"%#x", 255, red("0xff")},
+		"space flag":     {"% d", 5, red(" 5")},
+		"quoted":         {"%8q", "a", "     " + red(`"a"`)},
+		"error":          {"%s", errors.New("oops"), red("oops")},
+		"nil":            {"%v", nil, red("<nil>")},
+		"stringer width": {"%-4v|", colors.Styled("x", colors.Bold), red("\x1b[1mx\x1b[22m") + "   |"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assertEqualValues(t, tt.want, fmt.Sprintf(tt.giveFormat, colors.Styled(tt.giveValue, colors.FgRed)))
+		})
+	}
+}
+
+func TestStyled_Disabled(t *testing.T) {
+	var colorsState = colors.Enabled()
+
+	defer colors.Enabled(colorsState)
+
+	colors.Enabled(false)
+
+	var v = colors.Styled("foo", colors.FgRed|colors.Bold)
+
+	assertEqualValues(t, "  foo", fmt.Sprintf("%5s", v))
+	assertEqualValues(t, "foo", v.String())
+	assertEqualValues(t, "foo", fmt.Sprint(v))
+}
@@ -0,0 +1,138 @@
+package colors
+
+import (
+	"math"
+	"strings"
+
+	"gh.tarampamp.am/colors/internal/grapheme"
+)
+
+// Space is a color space, used for the colors interpolation.
+type Space uint8
+
+const (
+	SpaceOKLab Space = iota // OKLab (perceptually uniform, the default)
+	SpaceOKLCH              // OKLCH (the hue goes around the color wheel, saturated colors stay vivid)
+	SpaceHSL                // HSL (the hue goes around the color wheel)
+	SpaceRGB                // sRGB (plain components interpolation)
+)
+
+// lerp returns the linear interpolation between a and b.
+func lerp(a, b, t float64) float64 { return a + (b-a)*t }
+
+// lerpHue returns the interpolation between two hues (in degrees) using the shortest path around the color wheel.
+func lerpHue(a, b, t float64) float64 {
+	var d = math.Mod(b-a+540, 360) - 180 //nolint:mnd
+
+	return normalizeHue(a + d*t)
+}
+
+// Interpolate returns the color between the a and b colors at the position t (0..1) in the color space.
+func (s Space) Interpolate(a, b Color, t float64) Color {
+	t = clamp01(t)
+
+	switch s {
+	case SpaceOKLCH:
+		var x, y = a.OKLCH(), b.OKLCH()
+
+		return OKLCH{L: lerp(x.L, y.L, t), C: lerp(x.C, y.C, t), H: lerpHue(x.H, y.H, t)}.Color()
+	case SpaceHSL:
+		var x, y = a.HSL(), b.HSL()
+
+		return HSL{H: lerpHue(x.H, y.H, t), S: lerp(x.S, y.S, t), L: lerp(x.L, y.L, t)}.Color()
+	case SpaceRGB:
+		var (
+			ar, ag, ab = a.rgbFloats()
+			br, bg, bb = b.rgbFloats()
+		)
+
+		return colorFromFloats(lerp(ar, br, t), lerp(ag, bg, t), lerp(ab, bb, t))
+	}
+
+	return a.Mix(b, t)
+}
+
+// Gradient is a color gradient across two or more color stops (evenly distributed).
+type Gradient struct {
+	Stops      []Color
+	Space      Space // The interpolation color space
+	Background bool  // Colorize the background instead of the foreground
+}
+
+// rainbowStops are the rainbow gradient color stops.
+var rainbowStops = []Color{ //nolint:gochecknoglobals // read-only
+	{255, 0, 0}, {255, 255, 0}, {0, 255, 0}, {0, 255, 255}, {0, 0, 255}, {255, 0, 255},
+}
+
+// Rainbow returns the rainbow (red to magenta) gradient.
+func Rainbow() Gradient { return Gradient{Stops: rainbowStops, Space: SpaceHSL} }
+
+// At returns the gradient color at the position t (0..1).
+func (g Gradient) At(t float64) Color {
+	switch len(g.Stops) {
+	case 0:
+		return Color{}
+	case 1:
+		return g.Stops[0]
+	}
+
+	var (
+		segments = float64(len(g.Stops) - 1)
+		pos      = clamp01(t) * segments
+		idx      = int(math.Min(math.Floor(pos), segments-1))
+	)
+
+	return g.Space.Interpolate(g.Stops[idx], g.Stops[idx+1], pos-float64(idx))
+}
+
+// Wrap colors every grapheme (user-perceived character) of the provided string with the interpolated gradient color.
+// The colors are rendered according to the current color profile (see CurrentProfile), so they fall back to the 256
+// or 16 colors palettes. The provided string will return without any modifications when colors are disabled.
+func (g Gradient) Wrap(s string) string { return g.WrapIf(Enabled(), s) }
+
+// WrapIf is the same as Wrap, but uses the provided colors state instead of the global one.
+func (g Gradient) WrapIf(enabled bool, s string) string {
+	var profile = CurrentProfile()
+
+	if !enabled || profile == ProfileNone || len(g.Stops) == 0 || s == "" {
+		return s
+	}
+
+	var (
+		total     = grapheme.Count(s)
+		buf       strings.Builder
+		prevStart string
+		reset     string
+	)
+
+	buf.Grow(len(s) * 20) //nolint:mnd
+
+	for i := 0; len(s) > 0; i++ {
+		var (
+			size = grapheme.Next(s)
+			t    float64
+		)
+
+		if total > 1 {
+			t = float64(i) / float64(total-1)
+		}
+
+		var start string
+
+		start, reset = ColorStyle{Color: g.At(t), Background: g.Background}.ColorCodes(profile)
+
+		if start != prevStart { // do not repeat the same codes (e.g. for the fallback colors)
+			buf.WriteString(start)
+
+			prevStart = start
+		}
+
+		buf.WriteString(s[:size])
+
+		s = s[size:]
+	}
+
+	buf.WriteString(reset)
+
+	return buf.String()
+}
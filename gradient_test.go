@@ -0,0 +1,110 @@
+package colors_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"gh.tarampamp.am/colors"
+)
+
+func ExampleGradient_Wrap() {
+	var colorsState, profile = colors.Enabled(), colors.CurrentProfile()
+
+	defer func() { colors.Enabled(colorsState); colors.CurrentProfile(profile) }() // restore the global state
+
+	colors.Enabled(true)
+	colors.CurrentProfile(colors.ProfileTrueColor)
+
+	var g = colors.Gradient{Stops: []colors.Color{{R: 255}, {B: 255}}, Space: colors.SpaceRGB}
+
+	fmt.Printf("%q\n", g.Wrap("abc"))
+
+	// output:
+	// "\x1b[38;2;255;0;0ma\x1b[38;2;128;0;128mb\x1b[38;2;0;0;255mc\x1b[39m"
+}
+
+func TestSpace_Interpolate(t *testing.T) {
+	var red, blue = colors.Color{R: 255}, colors.Color{B: 255}
+
+	for _, space := range []colors.Space{colors.SpaceOKLab, colors.SpaceOKLCH, colors.SpaceHSL, colors.SpaceRGB} {
+		assertEqualValues(t, red, space.Interpolate(red, blue, 0))
+		assertEqualValues(t, blue, space.Interpolate(red, blue, 1))
+		assertEqualValues(t, blue, space.Interpolate(red, blue, 1.5)) // clamped
+	}
+
+	assertEqualValues(t, colors.Color{R: 128, B: 128}, colors.SpaceRGB.Interpolate(red, blue, 0.5))
+	assertEqualValues(t, colors.Color{R: 255, B: 255}, colors.SpaceHSL.Interpolate(red, blue, 0.5)) // shortest hue path
+	assertEqualValues(t, red.Mix(blue, 0.3), colors.SpaceOKLab.Interpolate(red, blue, 0.3))
+}
+
+func TestGradient_At(t *testing.T) {
+	var red, green, blue = colors.Color{R: 255}, colors.Color{G: 255}, colors.Color{B: 255}
+
+	assertEqualValues(t, colors.Color{}, colors.Gradient{}.At(0.5))
+	assertEqualValues(t, red, colors.Gradient{Stops: []colors.Color{red}}.At(0.5))
+
+	var g = colors.Gradient{Stops: []colors.Color{red, green, blue}, Space: colors.SpaceRGB}
+
+	assertEqualValues(t, red, g.At(0))
+	assertEqualValues(t, green, g.At(0.5))
+	assertEqualValues(t, blue, g.At(1))
+	assertEqualValues(t, colors.Color{R: 128, G: 128}, g.At(0.25))
+	assertEqualValues(t, red, g.At(-1))
+
+	var rainbow = colors.Rainbow()
+
+	assertEqualValues(t, red, rainbow.At(0))
+	assertEqualValues(t, colors.Color{R: 255, B: 255}, rainbow.At(1))
+}
+
+func TestGradient_Wrap(t *testing.T) {
+	var (
+		colorsState = colors.Enabled()
+		profile     = colors.CurrentProfile()
+		g           = colors.Gradient{Stops: []colors.Color{{R: 255}, {B: 255}}, Background: true}
+	)
+
+	defer func() { colors.Enabled(colorsState); colors.CurrentProfile(profile) }()
+
+	colors.Enabled(true)
+	colors.CurrentProfile(colors.ProfileTrueColor)
+
+	assertEqualValues(t, "", g.Wrap(""))
+	assertEqualValues(t, "\x1b[48;2;255;0;0mx\x1b[49m", g.Wrap("x"))
+
+	var got = g.Wrap("é\U0001F1FA\U0001F1E6z") // graphemes are not split
+
+	assertEqualValues(t, 3, strings.Count(got, "\x1b[48;2;"))
+	assertTrue(t, strings.Contains(got, "é\x1b["))
+	assertTrue(t, strings.HasSuffix(got, "z\x1b[49m"))
+
+	colors.CurrentProfile(colors.Profile256)
+
+	assertEqualValues(t, "\x1b[48;5;196mab\x1b[48;5;21mc\x1b[49m", colors.Gradient{
+		Stops: []colors.Color{{R: 255}, {R: 255}, {B: 255}}, Background: true,
+	}.Wrap("abc"))
+
+	colors.CurrentProfile(colors.Profile16)
+
+	assertEqualValues(t, "\x1b[91maaaa\x1b[39m", colors.Gradient{Stops: []colors.Color{{R: 255}}}.Wrap("aaaa"))
+
+	colors.CurrentProfile(colors.ProfileNone)
+
+	assertEqualValues(t, "abc", g.Wrap("abc"))
+
+	colors.CurrentProfile(colors.ProfileTrueColor)
+	colors.Enabled(false)
+
+	assertEqualValues(t, "abc", g.Wrap("abc"))
+	assertEqualValues(t, "abc", colors.Gradient{}.Wrap("abc"))
+
+	assertEqualValues(t, "\x1b[48;2;255;0;0mx\x1b[49m", g.WrapIf(true, "x"))
+	assertEqualValues(t, "\x1b[48;2;255;0;0mx\x1b[49m", g.WrapContext(colors.WithEnabled(context.Background(), true), "x"))
+
+	colors.Enabled(true)
+
+	assertEqualValues(t, "x", g.WrapIf(false, "x"))
+	assertEqualValues(t, "x", g.WrapContext(colors.WithEnabled(context.Background(), false), "x"))
+}
@@ -0,0 +1,295 @@
+// Package highlight provides the syntax highlighting for the Go source code (snippets), using the go/scanner
+// tokenizer and the themeable set of colors.TextStyle. It supports line numbers and highlighting of the line/column
+// span (e.g. for the error reporting).
+package highlight
+
+import (
+	"context"
+	"go/scanner"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"gh.tarampamp.am/colors"
+)
+
+// Theme is a set of the styles for the source code tokens.
+type Theme struct {
+	Keyword    colors.TextStyle // Keywords (func, return, etc.)
+	Ident      colors.TextStyle // Identifiers
+	Builtin    colors.TextStyle // Predeclared identifiers (int, len, nil, true, etc.)
+	String     colors.TextStyle // String and rune literals
+	Number     colors.TextStyle // Integer, floating-point and imaginary literals
+	Comment    colors.TextStyle // Comments
+	Operator   colors.TextStyle // Operators and punctuation
+	LineNumber colors.TextStyle // Line numbers (and the gutter separator)
+
+	Highlight           colors.TextStyle // Highlighted span (its colors override the tokens colors)
+	HighlightLineNumber colors.TextStyle // Line numbers of the highlighted span lines
+}
+
+// DefaultTheme is the default theme.
+var DefaultTheme = Theme{ //nolint:gochecknoglobals
+	Keyword:             colors.FgMagenta | colors.Bold,
+	Builtin:             colors.FgCyan,
+	String:              colors.FgGreen,
+	Number:              colors.FgYellow,
+	Comment:             colors.Faint | colors.Italic,
+	Operator:            colors.FgBlue,
+	LineNumber:          colors.Faint,
+	Highlight:           colors.FgRed | colors.Bold | colors.Underline,
+	HighlightLineNumber: colors.FgRed | colors.Bold,
+}
+
+// Span is a source code span, used for highlighting. Lines and columns are 1-based, columns are measured in bytes
+// (as the go/token package does).
+type Span struct {
+	Line, Col       int // Start position (the whole line is highlighted if Col is zero)
+	EndLine, EndCol int // End position (exclusive), the same line if EndLine is zero, the line end if EndCol is zero
+}
+
+// Highlighter highlights the Go source code.
+//
+//	var h = highlight.Highlighter{Theme: highlight.DefaultTheme, LineNumbers: true}
+//
+//	h.Span = highlight.Span{Line: 3, Col: 9, EndCol: 14} // e.g. the compilation error position
+//
+//	fmt.Print(h.Highlight(src))
+type Highlighter struct {
+	Theme       Theme  // Tokens styles
+	LineNumbers bool   // Render the line numbers
+	Lines       [2]int // Range of lines to render (1-based, inclusive), all lines if zero
+	Span        Span   // Span to highlight (nothing is highlighted if the Span.Line is zero)
+}
+
+// Go highlights the Go source code using the default theme.
+func Go(src string) string { return Highlighter{Theme: DefaultTheme}.Highlight(src) }
+
+// predeclared are the predeclared identifiers of the Go language.
+var predeclared = map[string]struct{}{ //nolint:gochecknoglobals // read-only
+	"any": {}, "bool": {}, "byte": {}, "comparable": {}, "complex64": {}, "complex128": {}, "error": {}, "float32": {},
+	"float64": {}, "int": {}, "int8": {}, "int16": {}, "int32": {}, "int64": {}, "rune": {}, "string": {}, "uint": {},
+	"uint8": {}, "uint16": {}, "uint32": {}, "uint64": {}, "uintptr": {}, "true": {}, "false": {}, "iota": {},
+	"nil": {}, "append": {}, "cap": {}, "clear": {}, "close": {}, "complex": {}, "copy": {}, "delete": {}, "imag": {},
+	"len": {}, "make": {}, "max": {}, "min": {}, "new": {}, "panic": {}, "print": {}, "println": {}, "real": {},
+	"recover": {},
+}
+
+// tokenStyle returns the style of the token.
+func (t Theme) tokenStyle(tok token.Token, lit string) colors.TextStyle {
+	switch {
+	case tok == token.COMMENT:
+		return t.Comment
+	case tok == token.STRING || tok == token.CHAR:
+		return t.String
+	case tok == token.INT || tok == token.FLOAT || tok == token.IMAG:
+		return t.Number
+	case tok == token.IDENT:
+		if _, ok := predeclared[lit]; ok {
+			return t.Builtin
+		}
+
+		return t.Ident
+	case tok.IsKeyword():
+		return t.Keyword
+	case tok.IsOperator():
+		return t.Operator
+	}
+
+	return 0
+}
+
+// styles returns the style of every source byte. Invalid code is tokenized as well (errors are ignored).
+func (h Highlighter) styles(src []byte) []colors.TextStyle {
+	var (
+		styles = make([]colors.TextStyle, len(src))
+		fset   = token.NewFileSet()
+		file   = fset.AddFile("", fset.Base(), len(src))
+		s      scanner.Scanner
+	)
+
+	s.Init(file, src, func(token.Position, string) {}, scanner.ScanComments)
+
+	for {
+		var pos, tok, lit = s.Scan()
+
+		if tok == token.EOF {
+			break
+		}
+
+		if tok == token.SEMICOLON && lit != ";" { // automatically inserted
+			continue
+		}
+
+		var (
+			start = file.Offset(pos)
+			end   = start + len(lit)
+		)
+
+		if lit == "" {
+			end = start + len(tok.String())
+		}
+
+		if tok == token.STRING || tok == token.COMMENT { // carriage returns are removed from the literals
+			end += strings.Count(string(src[start:min(end, len(src))]), "\r")
+		}
+
+		var style = h.Theme.tokenStyle(tok, lit)
+
+		for i := start; i < min(end, len(src)); i++ {
+			styles[i] = style
+		}
+	}
+
+	return styles
+}
+
+// overlay applies the top style over the base one: the top colors replace the base colors, and the attributes are
+// combined.
+func overlay(base, top colors.TextStyle) colors.TextStyle {
+	if top&colors.FgMask != 0 {
+		base &^= colors.FgMask
+	}
+
+	if top&colors.BgMask != 0 {
+		base &^= colors.BgMask
+	}
+
+	return base | top
+}
+
+// highlighted returns the highlighted columns range [from, to) of the line (0-based byte offsets within the line).
+func (sp Span) highlighted(line, length int) (from, to int, ok bool) {
+	var endLine = sp.EndLine
+
+	if endLine < sp.Line {
+		endLine = sp.Line
+	}
+
+	if sp.Line <= 0 || line < sp.Line || line > endLine {
+		return 0, 0, false
+	}
+
+	from, to = 0, length
+
+	if line == sp.Line && sp.Col > 0 {
+		from = min(sp.Col-1, length)
+	}
+
+	if line == endLine && sp.EndCol > 0 {
+		to = min(sp.EndCol-1, length)
+	}
+
+	if sp.Col > 0 && sp.EndCol == 0 && sp.EndLine == 0 && line == sp.Line && from < length { // a single position
+		to = from + 1
+	}
+
+	return from, max(to, from), true
+}
+
+// Highlight returns the highlighted source code. Every line ends with a line break. The source code is returned
+// without styling (but with the line numbers, if enabled) when colors are disabled (see colors.Enabled).
+func (h Highlighter) Highlight(src string) string { return h.HighlightIf(colors.Enabled(), src) }
+
+// HighlightContext is the same as Highlight, but respects the colors state carried by the context (see
+// colors.WithEnabled).
+func (h Highlighter) HighlightContext(ctx context.Context, src string) string {
+	return h.HighlightIf(colors.EnabledContext(ctx), src)
+}
+
+// HighlightIf is the same as Highlight, but uses the provided colors state instead of the global one.
+func (h Highlighter) HighlightIf(enabled bool, src string) string {
+	var (
+		styles = h.styles([]byte(src))
+		lines  = strings.SplitAfter(src, "\n")
+		first  = 1
+		last   = len(lines)
+		buf    strings.Builder
+	)
+
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		last--
+	}
+
+	if h.Lines[0] > 0 {
+		first = h.Lines[0]
+	}
+
+	if h.Lines[1] > 0 {
+		last = min(last, h.Lines[1])
+	}
+
+	var (
+		numWidth = len(strconv.Itoa(last))
+		offset   int // the line offset in the source
+	)
+
+	for n := 1; n <= last; n++ {
+		var line = lines[n-1]
+
+		if n >= first {
+			var (
+				content               = strings.TrimRight(line, "\r\n")
+				lineStyles            = styles[offset : offset+len(content)]
+				from, to, highlighted = h.Span.highlighted(n, len(content))
+			)
+
+			if h.LineNumbers {
+				h.writeGutter(&buf, n, numWidth, highlighted, enabled)
+			}
+
+			writeStyled(&buf, enabled, content, lineStyles, func(i int, style colors.TextStyle) colors.TextStyle {
+				if highlighted && i >= from && i < to {
+					return overlay(style, h.Theme.Highlight)
+				}
+
+				return style
+			})
+
+			buf.WriteByte('\n')
+		}
+
+		offset += len(line)
+	}
+
+	return buf.String()
+}
+
+// writeGutter writes the line number and the separator.
+func (h Highlighter) writeGutter(buf *strings.Builder, n, width int, highlighted, enabled bool) {
+	var (
+		num    = strconv.Itoa(n)
+		style  = h.Theme.LineNumber
+		marker = "  "
+	)
+
+	if highlighted {
+		style, marker = h.Theme.HighlightLineNumber, "> "
+	}
+
+	buf.WriteString(style.WrapIf(enabled, marker+strings.Repeat(" ", width-len(num))+num))
+	buf.WriteString(h.Theme.LineNumber.WrapIf(enabled, " | "))
+}
+
+// writeStyled writes the text, styled per byte (runs of the same style are wrapped together).
+func writeStyled(
+	buf *strings.Builder,
+	enabled bool,
+	text string,
+	styles []colors.TextStyle,
+	fn func(int, colors.TextStyle) colors.TextStyle,
+) {
+	for i := 0; i < len(text); {
+		var (
+			style = fn(i, styles[i])
+			j     = i + 1
+		)
+
+		for j < len(text) && fn(j, styles[j]) == style {
+			j++
+		}
+
+		buf.WriteString(style.WrapIf(enabled, text[i:j]))
+		i = j
+	}
+}
@@ -0,0 +1,166 @@
+package highlight_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"gh.tarampamp.am/colors"
+	"gh.tarampamp.am/colors/colorstest"
+	"gh.tarampamp.am/colors/highlight"
+)
+
+func ExampleHighlighter_Highlight() {
+	colors.Enabled(false) // change to true to see colors
+
+	var h = highlight.Highlighter{
+		Theme:       highlight.DefaultTheme,
+		LineNumbers: true,
+		Span:        highlight.Span{Line: 2, Col: 9, EndCol: 12},
+	}
+
+	fmt.Print(h.Highlight("func main() {\n\tvar x = foo()\n}\n"))
+
+	// output:
+	//   1 | func main() {
+	// > 2 | 	var x = foo()
+	//   3 | }
+}
+
+func TestGo(t *testing.T) {
+	colorstest.Force(t, true)
+
+	for name, tt := range map[string]struct {
+		give, want string
+	}{
+		"keywords": {
+			give: "package main",
+			want: "[bold,magenta]package[/] main\n",
+		},
+		"builtins": {
+			give: "x := len(s) + 1.5i",
+			want: "x [blue]:=[/] [cyan]len[/][blue]([/]s[blue])[/] [blue]+[/] [yellow]1.5i[/]\n",
+		},
+		"strings": {
+			give: "s = \"a\\\"b\" + 'c'",
+			want: "s [blue]=[/] [green]\"a\\\"b\"[/] [blue]+[/] [green]'c'[/]\n",
+		},
+		"comments": {
+			give: "x++ // inc\n/* a\nb */",
+			want: "x[blue]++[/] [faint,italic]// inc[/]\n[faint,italic]/* a[/]\n[faint,italic]b */[/]\n",
+		},
+		"raw string": {
+			give: "`a\r\nb` // c",
+			want: "[green]`a[/]\n[green]b`[/] [faint,italic]// c[/]\n",
+		},
+		"invalid code": {
+			give: "x = \"unterminated\ny = 1 @ 2",
+			want: "x [blue]=[/] [green]\"unterminated[/]\ny [blue]=[/] [yellow]1[/] @ [yellow]2[/]\n",
+		},
+		"empty": {},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := colorstest.Markup(highlight.Go(tt.give)); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestHighlighter_Highlight(t *testing.T) {
+	colorstest.Force(t, true)
+
+	const src = "package main\n\nfunc main() {\n\tprintln(\"hi\")\n}\n"
+
+	for name, tt := range map[string]struct {
+		give highlight.Highlighter
+		want string
+	}{
+		"no theme": {
+			give: highlight.Highlighter{},
+			want: src,
+		},
+		"line numbers": {
+			give: highlight.Highlighter{LineNumbers: true, Lines: [2]int{2, 3}},
+			want: "  2 | \n  3 | func main() {\n",
+		},
+		"line numbers width": {
+			give: highlight.Highlighter{LineNumbers: true, Lines: [2]int{5, 12}},
+			want: "  5 | }\n",
+		},
+		"styled gutter": {
+			give: highlight.Highlighter{
+				Theme:       highlight.Theme{LineNumber: colors.Faint, HighlightLineNumber: colors.FgRed},
+				LineNumbers: true,
+				Lines:       [2]int{4, 4},
+				Span:        highlight.Span{Line: 4},
+			},
+			want: "[red]> 4[/][faint] | \t[/]println(\"hi\")\n",
+		},
+		"single position": {
+			give: highlight.Highlighter{
+				Theme: highlight.Theme{Highlight: colors.Underline},
+				Lines: [2]int{4, 4},
+				Span:  highlight.Span{Line: 4, Col: 2},
+			},
+			want: "\t[underline]p[/]rintln(\"hi\")\n",
+		},
+		"span over tokens": {
+			give: highlight.Highlighter{
+				Theme: highlight.Theme{Builtin: colors.FgCyan | colors.Bold, Highlight: colors.FgRed | colors.Underline},
+				Lines: [2]int{4, 4},
+				Span:  highlight.Span{Line: 4, Col: 5, EndCol: 11},
+			},
+			want: "\t[bold,cyan]pri[/][bold,underline,red]ntln[/][underline,red](\"[/]hi\")\n",
+		},
+		"multiline span": {
+			give: highlight.Highlighter{
+				Theme: highlight.Theme{Highlight: colors.Reverse},
+				Lines: [2]int{3, 5},
+				Span:  highlight.Span{Line: 3, Col: 13, EndLine: 5, EndCol: 2},
+			},
+			want: "func main() [reverse]{[/]\n\t[reverse]println(\"hi\")[/]\n[reverse]}[/]\n",
+		},
+		"span out of range": {
+			give: highlight.Highlighter{
+				Theme: highlight.Theme{Highlight: colors.Reverse},
+				Lines: [2]int{5, 5},
+				Span:  highlight.Span{Line: 5, Col: 10, EndCol: 20},
+			},
+			want: "}\n",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := colorstest.Markup(tt.give.Highlight(src)); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestHighlighter_HighlightIf(t *testing.T) {
+	var h = highlight.Highlighter{Theme: highlight.DefaultTheme, LineNumbers: true}
+
+	const (
+		src    = "x := 1"
+		plain  = "  1 | x := 1\n"
+		styled = "[faint]  1 | [/]x [blue]:=[/] [yellow]1[/]\n"
+	)
+
+	for _, global := range []bool{true, false} { // the global colors state does not matter
+		colorstest.Force(t, global)
+
+		for _, tt := range []struct {
+			got, want string
+		}{
+			{h.HighlightIf(true, src), styled},
+			{h.HighlightIf(false, src), plain},
+			{h.HighlightContext(colors.WithEnabled(context.Background(), true), src), styled},
+			{h.HighlightContext(colors.WithEnabled(context.Background(), false), src), plain},
+		} {
+			if got := colorstest.Markup(tt.got); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Package grapheme provides a lightweight (simplified) segmentation of strings into user-perceived characters
+// (grapheme clusters). It handles combining marks, variation selectors, emoji modifiers, zero-width joiner sequences
+// and regional indicator (flag) pairs, which covers the vast majority of the real-world terminal output.
+package grapheme
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	zwj = '\u200d' // zero-width joiner
+
+	regionalIndicatorFirst, regionalIndicatorLast = '\U0001F1E6', '\U0001F1FF'
+	emojiModifierFirst, emojiModifierLast         = '\U0001F3FB', '\U0001F3FF'
+)
+
+// isRegionalIndicator returns true if the rune is a regional indicator symbol (used in flag emojis).
+func isRegionalIndicator(r rune) bool {
+	return r >= regionalIndicatorFirst && r <= regionalIndicatorLast
+}
+
+// IsExtender returns true if the rune extends the previous grapheme cluster (combining marks, variation selectors,
+// emoji modifiers, etc.).
+func IsExtender(r rune) bool {
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc):
+		return true
+	case r >= '\ufe00' && r <= '\ufe0f', r >= '\U000E0100' && r <= '\U000E01EF': // variation selectors
+		return true
+	case r >= emojiModifierFirst && r <= emojiModifierLast:
+		return true
+	case r >= '\U000E0020' && r <= '\U000E007F': // tags (used in subdivision flags)
+		return true
+	case r == zwj:
+		return true
+	}
+
+	return false
+}
+
+// Next returns the byte length of the first grapheme cluster in the string (0 for an empty string).
+func Next(s string) int {
+	if len(s) == 0 {
+		return 0
+	}
+
+	var first, size = utf8.DecodeRuneInString(s)
+
+	if first == '\r' && len(s) > 1 && s[1] == '\n' {
+		return 2 //nolint:mnd
+	}
+
+	if isRegionalIndicator(first) { // flags are pairs of regional indicators
+		if r, n := utf8.DecodeRuneInString(s[size:]); isRegionalIndicator(r) {
+			size += n
+		}
+	}
+
+	for size < len(s) {
+		var r, n = utf8.DecodeRuneInString(s[size:])
+
+		if !IsExtender(r) {
+			break
+		}
+
+		size += n
+
+		if r == zwj && size < len(s) { // the joined rune is a part of the cluster
+			_, n = utf8.DecodeRuneInString(s[size:])
+			size += n
+		}
+	}
+
+	return size
+}
+
+// Count returns the number of grapheme clusters in the string.
+func Count(s string) (n int) {
+	for len(s) > 0 {
+		s, n = s[Next(s):], n+1
+	}
+
+	return n
+}
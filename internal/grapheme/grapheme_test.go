@@ -0,0 +1,67 @@
+package grapheme_test
+
+import (
+	"testing"
+
+	"gh.tarampamp.am/colors/internal/grapheme"
+)
+
+func TestNext(t *testing.T) {
+	const family = "\U0001F468\u200d\U0001F469\u200d\U0001F467"
+
+	for name, tt := range map[string]struct {
+		give      string
+		wantFirst string
+		wantCount int
+	}{
+		"empty":             {"", "", 0},
+		"ascii":             {"abc", "a", 3},
+		"cyrillic":          {"привет", "п", 6},
+		"combining accent":  {"e\u0301x", "e\u0301", 2},
+		"crlf":              {"\r\nx", "\r\n", 2},
+		"flag":              {"\U0001F1FA\U0001F1E6!", "\U0001F1FA\U0001F1E6", 2},
+		"two flags":         {"\U0001F1FA\U0001F1E6\U0001F1E9\U0001F1EA", "\U0001F1FA\U0001F1E6", 2},
+		"emoji modifier":    {"\U0001F44D\U0001F3FD.", "\U0001F44D\U0001F3FD", 2},
+		"zwj sequence":      {family + "x", family, 2},
+		"variation":         {"❤\ufe0fx", "❤\ufe0f", 2},
+		"cjk":               {"日本語", "日", 3},
+		"lone combining":    {"\u0301a", "\u0301", 2},
+		"zwj at the end":    {"a\u200d", "a\u200d", 1},
+		"invalid utf8 byte": {"\xffa", "\xff", 2},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.give[:grapheme.Next(tt.give)]; got != tt.wantFirst {
+				t.Errorf("expected first cluster %q, got %q", tt.wantFirst, got)
+			}
+
+			if got := grapheme.Count(tt.give); got != tt.wantCount {
+				t.Errorf("expected %d clusters, got %d", tt.wantCount, got)
+			}
+		})
+	}
+}
+
+func TestWidth(t *testing.T) {
+	for give, want := range map[string]int{
+		"":                           0,
+		"a":                          1,
+		"\t":                         0,
+		"\x7f":                       0,
+		"e\u0301":                    1,
+		"\u0301":                     0,
+		"日":                          2,
+		"한":                          2,
+		"ｱ":                          1, // halfwidth katakana
+		"Ａ":                          2, // fullwidth latin
+		"\U0001F600":                 2,
+		"\U0001F1FA\U0001F1E6":       2,
+		"❤\ufe0f":                    2,
+		"❤":                          1,
+		"\U0001F44D\U0001F3FD":       2,
+		"\U0001F468\u200d\U0001F469": 2,
+	} {
+		if got := grapheme.Width(give); got != want {
+			t.Errorf("width of %q: expected %d, got %d", give, want, got)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package termsize_test
+
+import (
+	"os"
+	"testing"
+
+	"gh.tarampamp.am/colors/internal/termsize"
+)
+
+func TestWidth(t *testing.T) {
+	// test for non-panic
+	w, ok := termsize.Width(os.Stdout.Fd())
+
+	t.Log("os.Stdout:", w, ok)
+
+	if ok && w <= 0 {
+		t.Fatal("the width must be positive")
+	}
+}
+
+func TestWidthNotTerminal(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _ = f.Close() }()
+
+	if _, ok := termsize.Width(f.Fd()); ok {
+		t.Fatal("should be false for a regular file")
+	}
+}
@@ -0,0 +1,15 @@
+//go:build (linux || darwin || freebsd || openbsd || netbsd || dragonfly || solaris || aix) && !appengine
+
+package termsize
+
+import "golang.org/x/sys/unix"
+
+// Width returns the width (in columns) of the terminal, associated with the file descriptor.
+func Width(fd uintptr) (int, bool) {
+	ws, err := unix.IoctlGetWinsize(int(fd), unix.TIOCGWINSZ) //nolint:gosec
+	if err != nil || ws.Col == 0 {
+		return 0, false
+	}
+
+	return int(ws.Col), true
+}
@@ -0,0 +1,20 @@
+//go:build windows && !appengine
+
+package termsize
+
+import "golang.org/x/sys/windows"
+
+// Width returns the width (in columns) of the terminal (console), associated with the file descriptor.
+func Width(fd uintptr) (int, bool) {
+	var info windows.ConsoleScreenBufferInfo
+
+	if err := windows.GetConsoleScreenBufferInfo(windows.Handle(fd), &info); err != nil {
+		return 0, false
+	}
+
+	if w := int(info.Window.Right - info.Window.Left + 1); w > 0 {
+		return w, true
+	}
+
+	return 0, false
+}
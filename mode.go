@@ -0,0 +1,86 @@
+package colors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ColorMode is a colors mode, usually set by the user using the `--color` flag (or environment variable). It
+// implements the flag.Value (and pflag.Value) and encoding.TextUnmarshaler interfaces, so it can be used with the
+// standard flag package, spf13/pflag or any env-based configuration library:
+//
+//	var mode colors.ColorMode // auto by default
+//
+//	flag.Var(&mode, "color", "when to use colors (auto|always|never)")
+//	flag.Parse()
+//
+//	mode.Apply(os.Stdout)
+type ColorMode uint8
+
+const (
+	ColorModeAuto   ColorMode = iota // Detect colors support (the same way as for the default colors state)
+	ColorModeAlways                  // Always use colors
+	ColorModeNever                   // Never use colors
+)
+
+// ErrUnknownColorMode is returned when the color mode cannot be parsed.
+var ErrUnknownColorMode = errors.New("unknown color mode")
+
+// String returns the color mode name.
+func (m ColorMode) String() string {
+	switch m {
+	case ColorModeAuto:
+		return "auto"
+	case ColorModeAlways:
+		return "always"
+	case ColorModeNever:
+		return "never"
+	}
+
+	return "unknown"
+}
+
+// Set parses the color mode from the string (case-insensitive). Implements the flag.Value interface.
+func (m *ColorMode) Set(s string) error {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "auto", "":
+		*m = ColorModeAuto
+	case "always":
+		*m = ColorModeAlways
+	case "never":
+		*m = ColorModeNever
+	default:
+		return fmt.Errorf("%w: %q (allowed: auto, always, never)", ErrUnknownColorMode, s)
+	}
+
+	return nil
+}
+
+// Type returns the value type name. Implements the pflag.Value interface.
+func (ColorMode) Type() string { return "mode" }
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (m *ColorMode) UnmarshalText(text []byte) error { return m.Set(string(text)) }
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (m ColorMode) MarshalText() ([]byte, error) { return []byte(m.String()), nil }
+
+// Enabled returns true if colors should be used for the output into the provided writer. In auto mode the same
+// detection logic as for the default colors state is used (environment variables, CI, TTY), but against the
+// provided writer instead of os.Stdout.
+func (m ColorMode) Enabled(w io.Writer) bool {
+	switch m {
+	case ColorModeAlways:
+		return true
+	case ColorModeNever:
+		return false
+	}
+
+	return detectColors(w)
+}
+
+// Apply sets the global colors state (see Enabled) according to the mode and the provided writer. Returns the new
+// state.
+func (m ColorMode) Apply(w io.Writer) bool { return Enabled(m.Enabled(w)) }
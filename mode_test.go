@@ -0,0 +1,113 @@
+package colors_test
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"gh.tarampamp.am/colors"
+)
+
+func ExampleColorMode() {
+	var (
+		mode colors.ColorMode
+		fs   = flag.NewFlagSet("app", flag.ContinueOnError)
+	)
+
+	fs.Var(&mode, "color", "when to use colors (auto|always|never)")
+
+	_ = fs.Parse([]string{"--color=never"})
+
+	fmt.Println(mode, mode.Enabled(os.Stdout))
+
+	// output:
+	// never false
+}
+
+func TestColorMode_Set(t *testing.T) {
+	for give, want := range map[string]colors.ColorMode{
+		"auto":     colors.ColorModeAuto,
+		"":         colors.ColorModeAuto,
+		"always":   colors.ColorModeAlways,
+		" ALWAYS ": colors.ColorModeAlways,
+		"never":    colors.ColorModeNever,
+		"Never":    colors.ColorModeNever,
+	} {
+		t.Run(give, func(t *testing.T) {
+			var mode = colors.ColorMode(100)
+
+			assertEqualValues(t, nil, mode.Set(give))
+			assertEqualValues(t, want, mode)
+
+			mode = colors.ColorMode(100)
+
+			assertEqualValues(t, nil, mode.UnmarshalText([]byte(give)))
+			assertEqualValues(t, want, mode)
+
+			text, err := mode.MarshalText()
+
+			assertEqualValues(t, nil, err)
+			assertEqualValues(t, want.String(), string(text))
+		})
+	}
+
+	var mode = colors.ColorModeNever
+
+	assertTrue(t, errors.Is(mode.Set("sometimes"), colors.ErrUnknownColorMode))
+	assertEqualValues(t, colors.ColorModeNever, mode) // not changed
+	assertEqualValues(t, "unknown", colors.ColorMode(100).String())
+	assertEqualValues(t, "mode", mode.Type())
+}
+
+func TestColorMode_Flag(t *testing.T) {
+	var (
+		mode colors.ColorMode
+		fs   = flag.NewFlagSet("test", flag.ContinueOnError)
+	)
+
+	fs.SetOutput(io.Discard)
+	fs.Var(&mode, "color", "")
+
+	assertEqualValues(t, nil, fs.Parse([]string{"--color", "always"}))
+	assertEqualValues(t, colors.ColorModeAlways, mode)
+	assertTrue(t, fs.Parse([]string{"--color", "foo"}) != nil)
+}
+
+func TestColorMode_Enabled(t *testing.T) {
+	t.Setenv("FORCE_COLOR", "")
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TERM", "xterm")
+
+	_ = os.Unsetenv("FORCE_COLOR") // restored by the t.Setenv cleanup
+	_ = os.Unsetenv("NO_COLOR")
+
+	var buf bytes.Buffer
+
+	assertTrue(t, colors.ColorModeAlways.Enabled(&buf))
+	assertFalse(t, colors.ColorModeNever.Enabled(&buf))
+	assertFalse(t, colors.ColorModeAuto.Enabled(&buf)) // not a terminal
+
+	t.Setenv("FORCE_COLOR", "1")
+
+	assertTrue(t, colors.ColorModeAuto.Enabled(&buf))
+
+	_ = os.Unsetenv("FORCE_COLOR")
+	t.Setenv("NO_COLOR", "1")
+
+	assertFalse(t, colors.ColorModeAuto.Enabled(os.Stdout))
+}
+
+func TestColorMode_Apply(t *testing.T) {
+	var colorsState = colors.Enabled()
+
+	defer colors.Enabled(colorsState)
+
+	assertTrue(t, colors.ColorModeAlways.Apply(io.Discard))
+	assertTrue(t, colors.Enabled())
+	assertFalse(t, colors.ColorModeNever.Apply(io.Discard))
+	assertFalse(t, colors.Enabled())
+}
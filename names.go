@@ -0,0 +1,57 @@
+package colors
+
+import "strings"
+
+// normalizeColorName converts the color name to the form used as a key in the named colors tables (lower-cased,
+// without spaces, dashes and underscores).
+func normalizeColorName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '_':
+			return -1
+		}
+
+		if r >= 'A' && r <= 'Z' {
+			return r + ('a' - 'A')
+		}
+
+		return r
+	}, name)
+}
+
+// ColorByName returns the color by its CSS or X11 name. The lookup is case-insensitive, and spaces, dashes and
+// underscores are ignored (so "SlateGray", "slate gray" and "slate-gray" are the same). CSS names take precedence
+// over the X11 ones (they differ for "gray", "green", "maroon" and "purple"), use X11ColorByName to look up the
+// X11 table only.
+//
+// The returned color can be used as a foreground or background style:
+//
+//	if c, ok := colors.ColorByName("rebeccapurple"); ok {
+//		fmt.Println(c.Fg().Wrap("Foo Bar"))
+//	}
+func ColorByName(name string) (Color, bool) {
+	var key = normalizeColorName(name)
+
+	if c, ok := cssColors[key]; ok {
+		return c, true
+	}
+
+	c, ok := x11Colors[key]
+
+	return c, ok
+}
+
+// CSSColorByName returns the color by its CSS name (see ColorByName for the lookup rules).
+func CSSColorByName(name string) (Color, bool) {
+	c, ok := cssColors[normalizeColorName(name)]
+
+	return c, ok
+}
+
+// X11ColorByName returns the color by its X11 name, including the numbered variants like "SlateGray3" and "gray42"
+// (see ColorByName for the lookup rules).
+func X11ColorByName(name string) (Color, bool) {
+	c, ok := x11Colors[normalizeColorName(name)]
+
+	return c, ok
+}
@@ -0,0 +1,78 @@
+package colors_test
+
+import (
+	"fmt"
+	"testing"
+
+	"gh.tarampamp.am/colors"
+)
+
+func ExampleColorByName() {
+	c, ok := colors.ColorByName("RebeccaPurple")
+
+	fmt.Println(c, ok)
+
+	// output:
+	// #663399 true
+}
+
+func TestColorByName(t *testing.T) {
+	for give, want := range map[string]colors.Color{
+		"orange":        {R: 255, G: 165},
+		"Orange":        {R: 255, G: 165},
+		"slategray":     {R: 112, G: 128, B: 144},
+		"Slate Gray":    {R: 112, G: 128, B: 144},
+		"slate-grey":    {R: 112, G: 128, B: 144},
+		"rebeccapurple": {R: 102, G: 51, B: 153},
+		"gray":          {R: 128, G: 128, B: 128}, // css
+		"green":         {G: 128},                 // css
+		"SlateGray3":    {R: 159, G: 182, B: 205}, // x11 only
+		"gray42":        {R: 107, G: 107, B: 107}, // x11 only
+		"navajo_white":  {R: 255, G: 222, B: 173},
+	} {
+		t.Run(give, func(t *testing.T) {
+			c, ok := colors.ColorByName(give)
+
+			assertTrue(t, ok)
+			assertEqualValues(t, want, c)
+		})
+	}
+
+	for _, give := range []string{"", "foo", "grey101", "slategray5"} {
+		_, ok := colors.ColorByName(give)
+
+		assertFalse(t, ok)
+	}
+}
+
+func TestCSSAndX11ColorByName(t *testing.T) {
+	for name, tt := range map[string]struct {
+		wantCSS, wantX11 colors.Color
+	}{
+		"gray":   {colors.Color{R: 128, G: 128, B: 128}, colors.Color{R: 190, G: 190, B: 190}},
+		"green":  {colors.Color{G: 128}, colors.Color{G: 255}},
+		"maroon": {colors.Color{R: 128}, colors.Color{R: 176, G: 48, B: 96}},
+		"purple": {colors.Color{R: 128, B: 128}, colors.Color{R: 160, G: 32, B: 240}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			css, ok := colors.CSSColorByName(name)
+
+			assertTrue(t, ok)
+			assertEqualValues(t, tt.wantCSS, css)
+
+			x11, ok := colors.X11ColorByName(name)
+
+			assertTrue(t, ok)
+			assertEqualValues(t, tt.wantX11, x11)
+		})
+	}
+
+	_, ok := colors.CSSColorByName("rebeccapurple")
+	assertTrue(t, ok)
+
+	_, ok = colors.X11ColorByName("rebeccapurple")
+	assertFalse(t, ok)
+
+	_, ok = colors.CSSColorByName("gray42")
+	assertFalse(t, ok)
+}
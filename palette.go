@@ -0,0 +1,138 @@
+package colors
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// Palette is a reference palette of the 16 base colors - 8 normal colors followed by 8 bright ones, in the ANSI
+// order (black, red, green, yellow, blue, magenta, cyan, white). Terminal themes render the base colors differently,
+// so the palette is used to resolve them to RGB, and to find the nearest base color for an arbitrary RGB one.
+type Palette [16]Color
+
+// Predefined palettes.
+var (
+	XtermPalette = Palette{ //nolint:gochecknoglobals // xterm defaults
+		{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0}, {0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+		{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0}, {92, 92, 255}, {255, 0, 255}, {0, 255, 255},
+		{255, 255, 255},
+	}
+	VGAPalette = Palette{ //nolint:gochecknoglobals // IBM VGA text mode
+		{0, 0, 0}, {170, 0, 0}, {0, 170, 0}, {170, 85, 0}, {0, 0, 170}, {170, 0, 170}, {0, 170, 170}, {170, 170, 170},
+		{85, 85, 85}, {255, 85, 85}, {85, 255, 85}, {255, 255, 85}, {85, 85, 255}, {255, 85, 255}, {85, 255, 255},
+		{255, 255, 255},
+	}
+	CampbellPalette = Palette{ //nolint:gochecknoglobals // Windows Terminal and Windows 10+ console defaults
+		{12, 12, 12}, {197, 15, 31}, {19, 161, 14}, {193, 156, 0}, {0, 55, 218}, {136, 23, 152}, {58, 150, 221},
+		{204, 204, 204}, {118, 118, 118}, {231, 72, 86}, {22, 198, 12}, {249, 241, 165}, {59, 120, 255},
+		{180, 0, 158}, {97, 214, 214}, {242, 242, 242},
+	}
+)
+
+var colorsPalette atomic.Pointer[Palette] //nolint:gochecknoglobals // atomic usage only
+
+// CurrentPalette returns the current reference palette (XtermPalette by default), used to map RGB colors down to the
+// base colors (see ColorStyle.TextStyle). Also, you can set a new palette.
+func CurrentPalette(newPalette ...Palette) Palette {
+	if len(newPalette) == 0 {
+		if p := colorsPalette.Load(); p != nil {
+			return *p
+		}
+
+		return XtermPalette
+	}
+
+	var p = newPalette[0]
+
+	colorsPalette.Store(&p)
+
+	return p
+}
+
+// Nearest returns the index (0..15) of the nearest base color. The perceptual distance (euclidean distance in the
+// OKLab color space) is used.
+func (p Palette) Nearest(c Color) (idx int) {
+	var (
+		lab  = c.OKLab()
+		best = math.Inf(1)
+	)
+
+	for i, pc := range p {
+		if dist := okLabDistance(lab, pc.OKLab()); dist < best {
+			best, idx = dist, i
+		}
+	}
+
+	return idx
+}
+
+// NearestFg returns the foreground text style of the nearest base color (e.g. FgRed|FgBright).
+func (p Palette) NearestFg(c Color) TextStyle { return paletteStyle(p.Nearest(c), FgBlack, FgBright) }
+
+// NearestBg returns the background text style of the nearest base color (e.g. BgRed|BgBright).
+func (p Palette) NearestBg(c Color) TextStyle { return paletteStyle(p.Nearest(c), BgBlack, BgBright) }
+
+// paletteStyle converts the palette index (0..15) to the text style, using the first (black) color and the bright
+// bit of the foreground or background colors set.
+func paletteStyle(idx int, first, bright TextStyle) TextStyle {
+	if idx >= 8 { //nolint:mnd
+		return first<<(idx-8) | bright
+	}
+
+	return first << idx
+}
+
+// xterm256Levels are the 6x6x6 color cube component levels of the xterm 256 colors palette.
+var xterm256Levels = [6]uint8{0, 95, 135, 175, 215, 255} //nolint:gochecknoglobals // read-only
+
+// Color256 returns the RGB color of the xterm 256 colors palette index. The first 16 colors are resolved using the
+// palette, the rest are the 6x6x6 color cube (16..231) and the grayscale ramp (232..255).
+func (p Palette) Color256(idx uint8) Color {
+	switch {
+	case idx < 16: //nolint:mnd
+		return p[idx]
+	case idx < 232: //nolint:mnd
+		idx -= 16
+
+		return Color{xterm256Levels[idx/36], xterm256Levels[idx/6%6], xterm256Levels[idx%6]}
+	}
+
+	var gray = 8 + (idx-232)*10 //nolint:mnd
+
+	return Color{gray, gray, gray}
+}
+
+// xterm256OKLab is a precomputed table of the xterm 256 colors palette indexes 16..255 in the OKLab color space.
+var xterm256OKLab = func() (t [240]OKLab) { //nolint:gochecknoglobals // read-only
+	for i := range t {
+		t[i] = XtermPalette.Color256(uint8(i + 16)).OKLab() //nolint:gosec,mnd
+	}
+
+	return t
+}()
+
+// Nearest256 returns the nearest xterm 256 colors palette index (using the perceptual distance, see Nearest). The
+// first 16 colors are never returned, since they are theme-dependent (use Nearest to map to them), so the result is
+// in the 16..255 range.
+func Nearest256(c Color) uint8 {
+	var (
+		lab  = c.OKLab()
+		best = math.Inf(1)
+		idx  int
+	)
+
+	for i, x := range xterm256OKLab {
+		if dist := okLabDistance(lab, x); dist < best {
+			best, idx = dist, i
+		}
+	}
+
+	return uint8(idx + 16) //nolint:gosec,mnd
+}
+
+// okLabDistance returns the squared euclidean distance between the colors in the OKLab color space.
+func okLabDistance(x, y OKLab) float64 {
+	var dl, da, db = x.L - y.L, x.A - y.A, x.B - y.B
+
+	return dl*dl + da*da + db*db
+}
@@ -0,0 +1,94 @@
+package colors_test
+
+import (
+	"fmt"
+	"testing"
+
+	"gh.tarampamp.am/colors"
+)
+
+func ExampleNearest256() {
+	fmt.Println(colors.Nearest256(colors.Color{R: 255, G: 100, B: 0}))
+
+	// output:
+	// 202
+}
+
+func TestNearest256(t *testing.T) {
+	for name, tt := range map[string]struct {
+		give colors.Color
+		want uint8
+	}{
+		"black":      {colors.Color{}, 16},
+		"white":      {colors.Color{R: 255, G: 255, B: 255}, 231},
+		"red":        {colors.Color{R: 255}, 196},
+		"steel blue": {colors.Color{R: 95, G: 135, B: 175}, 67},
+		"gray 128":   {colors.Color{R: 128, G: 128, B: 128}, 244},
+		"gray 130":   {colors.Color{R: 130, G: 130, B: 130}, 244},
+		"dark gray":  {colors.Color{R: 5, G: 5, B: 5}, 232},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assertEqualValues(t, tt.want, colors.Nearest256(tt.give))
+		})
+	}
+
+	for i := 16; i < 256; i++ { // round trip
+		assertEqualValues(t, uint8(i), colors.Nearest256(colors.XtermPalette.Color256(uint8(i))))
+	}
+}
+
+func TestPalette_Color256(t *testing.T) {
+	assertEqualValues(t, colors.XtermPalette[9], colors.XtermPalette.Color256(9))
+	assertEqualValues(t, colors.VGAPalette[3], colors.VGAPalette.Color256(3))
+	assertEqualValues(t, colors.Color{}, colors.XtermPalette.Color256(16))
+	assertEqualValues(t, colors.Color{R: 255, G: 255, B: 255}, colors.XtermPalette.Color256(231))
+	assertEqualValues(t, colors.Color{R: 8, G: 8, B: 8}, colors.XtermPalette.Color256(232))
+	assertEqualValues(t, colors.Color{R: 238, G: 238, B: 238}, colors.XtermPalette.Color256(255))
+}
+
+func TestPalette_Nearest(t *testing.T) {
+	for i, c := range colors.XtermPalette { // exact matches
+		assertEqualValues(t, i, colors.XtermPalette.Nearest(c))
+	}
+
+	var brown = colors.Color{R: 170, G: 85}
+
+	assertEqualValues(t, colors.FgYellow, colors.VGAPalette.NearestFg(brown))
+	assertEqualValues(t, colors.BgYellow, colors.VGAPalette.NearestBg(brown))
+
+	var lightBlue = colors.Color{R: 59, G: 120, B: 255}
+
+	assertEqualValues(t, colors.FgBlue|colors.FgBright, colors.CampbellPalette.NearestFg(lightBlue))
+	assertEqualValues(t, colors.BgBlue|colors.BgBright, colors.CampbellPalette.NearestBg(lightBlue))
+
+	assertEqualValues(t, colors.FgBlack, colors.XtermPalette.NearestFg(colors.Color{R: 10, G: 10, B: 10}))
+	var almostWhite = colors.Color{R: 250, G: 250, B: 250}
+
+	assertEqualValues(t, colors.FgWhite|colors.FgBright, colors.XtermPalette.NearestFg(almostWhite))
+}
+
+func TestCurrentPalette(t *testing.T) {
+	var palette = colors.CurrentPalette()
+
+	defer colors.CurrentPalette(palette)
+
+	assertEqualValues(t, colors.VGAPalette, colors.CurrentPalette(colors.VGAPalette))
+	assertEqualValues(t, colors.VGAPalette, colors.CurrentPalette())
+	assertEqualValues(t, colors.FgYellow, colors.Color{R: 170, G: 85}.Fg().TextStyle())
+
+	colors.CurrentPalette(colors.XtermPalette)
+
+	assertEqualValues(t, colors.XtermPalette, colors.CurrentPalette())
+}
+
+func TestColorStyle_ColorCodes256(t *testing.T) {
+	var start, reset = colors.Color{R: 255}.Fg().ColorCodes(colors.Profile256)
+
+	assertEqualValues(t, "\x1b[38;5;196m", start)
+	assertEqualValues(t, "\x1b[39m", reset)
+
+	start, reset = colors.Color{R: 128, G: 128, B: 128}.Bg().ColorCodes(colors.Profile256)
+
+	assertEqualValues(t, "\x1b[48;5;244m", start)
+	assertEqualValues(t, "\x1b[49m", reset)
+}
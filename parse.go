@@ -0,0 +1,242 @@
+package colors
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidSGR is returned when the string is not an SGR (Select Graphic Rendition) sequence.
+	ErrInvalidSGR = errors.New("invalid SGR sequence")
+
+	// ErrUnsupportedCode is returned when the SGR code can not be represented by the TextStyle (e.g. 256 and RGB
+	// colors).
+	ErrUnsupportedCode = errors.New("unsupported SGR code")
+)
+
+// ParseStyle parses the SGR sequence (e.g. "\x1b[1;91;44m", or just the codes "1;91;44") into the text style (e.g.
+// Bold|FgRed|FgBright|BgBlue). It's the inverse of the TextStyle.ColorCodes (see StyleFromCodes for the details).
+//
+// The codes with the colon separated sub-parameters (e.g. "4:3" for the curly underline, or "38:2::1:2:3" for the RGB
+// color) are applied using the main code (except the extended colors), and reported as unsupported, since the
+// sub-parameters can not be represented by the TextStyle.
+func ParseStyle(s string) (TextStyle, error) {
+	var params = s
+
+	if strings.HasPrefix(s, "\x1b[") {
+		if !isSGR(s) {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidSGR, s)
+		}
+
+		params = s[2 : len(s)-1]
+	}
+
+	if params == "" {
+		return StyleFromCodes()
+	}
+
+	var (
+		style       TextStyle
+		reset       bool
+		codes       []int    // pending codes (without sub-parameters)
+		unsupported []string // unsupported codes, in the order of appearance
+	)
+
+	var flush = func() { // applies the pending codes
+		if len(codes) > 0 {
+			style, reset, _ = style.applySGR(codes, func(c []int) { unsupported = append(unsupported, joinCodes(c)) })
+			codes = codes[:0]
+		}
+	}
+
+	for _, part := range strings.Split(params, ";") {
+		var mainCode, subCodes, hasSub = strings.Cut(part, ":")
+
+		code, ok := parseCode(mainCode)
+		if !ok {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidSGR, s)
+		}
+
+		if !hasSub {
+			codes = append(codes, code)
+
+			continue
+		}
+
+		for _, sub := range strings.Split(subCodes, ":") {
+			if _, ok = parseCode(sub); !ok {
+				return 0, fmt.Errorf("%w: %q", ErrInvalidSGR, s)
+			}
+		}
+
+		flush()
+
+		// the main code only, the sub-parameters are reported (the extended colors are skipped entirely)
+		style, reset, _ = style.applySGR([]int{code}, nil)
+		unsupported = append(unsupported, part)
+	}
+
+	flush()
+
+	if reset {
+		style = Reset
+	}
+
+	if len(unsupported) > 0 {
+		return style, unsupportedError(unsupported)
+	}
+
+	return style, nil
+}
+
+// parseCode parses the SGR code (or sub-parameter). An omitted code is treated as zero.
+func parseCode(s string) (int, bool) {
+	if s == "" {
+		return 0, true
+	}
+
+	code, err := strconv.Atoi(s)
+	if err != nil || code < 0 {
+		return 0, false
+	}
+
+	return code, true
+}
+
+// StyleFromCodes converts the SGR codes into the text style. No codes (or the trailing 0 code) means Reset. See
+// TextStyle.ApplyCodes for the details of how the codes are applied.
+func StyleFromCodes(codes ...int) (TextStyle, error) {
+	if len(codes) == 0 {
+		return Reset, nil
+	}
+
+	var style, reset, err = TextStyle(0).applyCodes(codes)
+
+	if reset {
+		style = Reset
+	}
+
+	return style, err
+}
+
+// ApplyCodes applies the SGR codes to the style, as terminals do: the 0 code clears the style, the attribute resets
+// (22-25, 27-29) clear the attribute bits, and the latter color codes override the former ones (including 39 and 49,
+// that set the default colors).
+//
+// The codes, that can not be represented by the TextStyle (e.g. 256 and RGB colors), are reported using the error
+// (wrapping the ErrUnsupportedCode), and the style with the rest of the codes applied is returned anyway.
+func (ts TextStyle) ApplyCodes(codes ...int) (TextStyle, error) {
+	var style, _, err = ts.applyCodes(codes)
+
+	return style, err
+}
+
+// StyleFromCodesOK is the same as StyleFromCodes, but the codes, that can not be represented by the TextStyle, are
+// reported using the ok flag (false) instead of the error. It does not allocate, so it's suitable for the hot paths
+// (e.g. the escape sequences parsers).
+func StyleFromCodesOK(codes ...int) (TextStyle, bool) {
+	if len(codes) == 0 {
+		return Reset, true
+	}
+
+	var style, reset, ok = TextStyle(0).applySGR(codes, nil)
+
+	if reset {
+		style = Reset
+	}
+
+	return style, ok
+}
+
+// applyCodes applies the SGR codes to the style. The reset flag is true, if the last applied code is 0 (the style is
+// reset, and nothing follows). The unsupported codes are reported using the error.
+func (ts TextStyle) applyCodes(codes []int) (_ TextStyle, reset bool, _ error) {
+	var unsupported []string
+
+	ts, reset, ok := ts.applySGR(codes, func(c []int) { unsupported = append(unsupported, joinCodes(c)) })
+	if !ok {
+		return ts, reset, unsupportedError(unsupported)
+	}
+
+	return ts, reset, nil
+}
+
+// applySGR applies the SGR codes to the style (see applyCodes). The codes, that can not be represented by the
+// TextStyle, are skipped and passed to the unsupported function (if not nil), and the ok flag is false in this case.
+func (ts TextStyle) applySGR(codes []int, unsupported func([]int)) (_ TextStyle, reset, ok bool) { //nolint:gocyclo
+	ok = true
+
+	for i := 0; i < len(codes); i++ {
+		reset = false
+
+		switch code := codes[i]; {
+		case code == 0:
+			ts, reset = 0, true
+		case code >= 1 && code <= 5:
+			ts |= [...]TextStyle{Bold, Faint, Italic, Underline, Blinking}[code-1]
+		case code >= 7 && code <= 9:
+			ts |= [...]TextStyle{Reverse, Invisible, Strike}[code-7]
+		case code == 22: //nolint:mnd
+			ts &^= Bold | Faint
+		case code >= 23 && code <= 29 && code != 26:
+			ts &^= [...]TextStyle{Italic, Underline, Blinking, 0, Reverse, Invisible, Strike}[code-23]
+		case code >= 30 && code <= 37:
+			ts = ts&^FgMask | FgBlack<<(code-30)
+		case code == 39: //nolint:mnd
+			ts = ts&^FgMask | FgDefault
+		case code >= 90 && code <= 97:
+			ts = ts&^FgMask | FgBlack<<(code-90) | FgBright
+		case code >= 40 && code <= 47:
+			ts = ts&^BgMask | BgBlack<<(code-40)
+		case code == 49: //nolint:mnd
+			ts = ts&^BgMask | BgDefault
+		case code >= 100 && code <= 107:
+			ts = ts&^BgMask | BgBlack<<(code-100) | BgBright
+		case code == 38 || code == 48 || code == 58: // extended colors, e.g. "38;5;n" or "38;2;r;g;b"
+			var n = 1
+
+			if i+1 < len(codes) {
+				switch codes[i+1] {
+				case 5: //nolint:mnd
+					n = 3
+				case 2: //nolint:mnd
+					n = 5
+				}
+			}
+
+			n, ok = min(n, len(codes)-i), false
+
+			if unsupported != nil {
+				unsupported(codes[i : i+n])
+			}
+
+			i += n - 1
+		default:
+			ok = false
+
+			if unsupported != nil {
+				unsupported(codes[i : i+1])
+			}
+		}
+	}
+
+	return ts, reset, ok
+}
+
+// unsupportedError returns the ErrUnsupportedCode wrapping error with the unsupported codes list.
+func unsupportedError(codes []string) error {
+	return fmt.Errorf("%w: %s", ErrUnsupportedCode, strings.Join(codes, ", "))
+}
+
+// joinCodes joins the codes with semicolons.
+func joinCodes(codes []int) string {
+	var parts = make([]string, len(codes))
+
+	for i, code := range codes {
+		parts[i] = strconv.Itoa(code)
+	}
+
+	return strings.Join(parts, ";")
+}
@@ -0,0 +1,200 @@
+package colors_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"gh.tarampamp.am/colors"
+)
+
+func ExampleParseStyle() {
+	var style, err = colors.ParseStyle("\x1b[1;91;44m")
+
+	fmt.Println(style == colors.Bold|colors.FgRed|colors.FgBright|colors.BgBlue, err)
+
+	_, err = colors.ParseStyle("\x1b[1;38;5;196;6m")
+
+	fmt.Println(err)
+
+	// output:
+	// true <nil>
+	// unsupported SGR code: 38;5;196, 6
+}
+
+func TestParseStyle_RoundTrip(t *testing.T) {
+	var (
+		attrs = []colors.TextStyle{0, colors.Bold, colors.Faint | colors.Italic, colors.Underline | colors.Blinking,
+			colors.Reverse | colors.Invisible | colors.Strike}
+		fgs = []colors.TextStyle{0, colors.FgBlack, colors.FgRed, colors.FgGreen, colors.FgYellow, colors.FgBlue,
+			colors.FgMagenta, colors.FgCyan, colors.FgWhite, colors.FgDefault}
+		bgs = []colors.TextStyle{0, colors.BgBlack, colors.BgRed, colors.BgGreen, colors.BgYellow, colors.BgBlue,
+			colors.BgMagenta, colors.BgCyan, colors.BgWhite, colors.BgDefault}
+	)
+
+	for _, attr := range attrs {
+		for _, fg := range fgs {
+			for _, bg := range bgs {
+				for _, bright := range []colors.TextStyle{0, colors.FgBright | colors.BgBright} {
+					var style = attr | fg | bg
+
+					if fg != 0 && fg != colors.FgDefault {
+						style |= bright & colors.FgBright
+					}
+
+					if bg != 0 && bg != colors.BgDefault {
+						style |= bright & colors.BgBright
+					}
+
+					if style == 0 {
+						continue
+					}
+
+					var start, _ = style.ColorCodes()
+
+					got, err := colors.ParseStyle(start)
+					if err != nil {
+						t.Fatalf("%q: unexpected error: %v", start, err)
+					}
+
+					if got != style {
+						t.Fatalf("%q: expected %032b, got %032b", start, style, got)
+					}
+				}
+			}
+		}
+	}
+
+	var start, _ = colors.Reset.ColorCodes()
+
+	got, err := colors.ParseStyle(start)
+
+	assertEqualValues(t, nil, err)
+	assertEqualValues(t, colors.Reset, got)
+
+	got, err = colors.ParseStyle("\x1b[0;1;31m") // the common subprocess output prefix
+
+	assertEqualValues(t, nil, err)
+	assertEqualValues(t, colors.Bold|colors.FgRed, got)
+
+	start, _ = got.ColorCodes()
+
+	assertEqualValues(t, "\x1b[1;31m", start)
+}
+
+func TestParseStyle(t *testing.T) {
+	for name, tt := range map[string]struct {
+		give    string
+		want    colors.TextStyle
+		wantErr error
+		errText string
+	}{
+		"empty sequence": {give: "\x1b[m", want: colors.Reset},
+		"empty codes":    {give: "", want: colors.Reset},
+		"codes only":     {give: "1;31", want: colors.Bold | colors.FgRed},
+		"omitted code":   {give: "\x1b[;1m", want: colors.Bold},
+		"reset and bold": {give: "\x1b[0;1m", want: colors.Bold},
+		"trailing reset": {give: "\x1b[1;31;0m", want: colors.Reset},
+		"omitted reset":  {give: "\x1b[1;m", want: colors.Reset},
+		"bold reset":     {give: "\x1b[1;22m", want: 0},
+		"attr resets":    {give: "\x1b[1;3;4;5;7;8;9;23;24;25;27;28;29m", want: colors.Bold},
+		"color override": {give: "\x1b[31;92;44;49m", want: colors.FgGreen | colors.FgBright | colors.BgDefault},
+		"leading zeroes": {give: "\x1b[01;031m", want: colors.Bold | colors.FgRed},
+		"rgb color": {
+			give: "\x1b[1;38;2;1;2;3;4m", want: colors.Bold | colors.Underline,
+			wantErr: colors.ErrUnsupportedCode, errText: "unsupported SGR code: 38;2;1;2;3",
+		},
+		"256 color": {
+			give: "\x1b[48;5;10m", wantErr: colors.ErrUnsupportedCode, errText: "unsupported SGR code: 48;5;10",
+		},
+		"truncated color": {
+			give: "\x1b[38;5m", wantErr: colors.ErrUnsupportedCode, errText: "unsupported SGR code: 38;5",
+		},
+		"bare color": {give: "\x1b[58m", wantErr: colors.ErrUnsupportedCode, errText: "unsupported SGR code: 58"},
+		"unsupported": {
+			give: "\x1b[21;26;6m", wantErr: colors.ErrUnsupportedCode, errText: "unsupported SGR code: 21, 26, 6",
+		},
+		"not sgr":  {give: "\x1b[2J", wantErr: colors.ErrInvalidSGR, errText: `invalid SGR sequence: "\x1b[2J"`},
+		"bad code": {give: "\x1b[1;x;2m", wantErr: colors.ErrInvalidSGR},
+		"underline style": {
+			give: "\x1b[4:3m", want: colors.Underline,
+			wantErr: colors.ErrUnsupportedCode, errText: "unsupported SGR code: 4:3",
+		},
+		"colon rgb color": {
+			give: "\x1b[1;38:2::1:2:3;4m", want: colors.Bold | colors.Underline,
+			wantErr: colors.ErrUnsupportedCode, errText: "unsupported SGR code: 38:2::1:2:3",
+		},
+		"colon color order": {
+			give: "\x1b[6;48:5:1;5;31m", want: colors.Blinking | colors.FgRed,
+			wantErr: colors.ErrUnsupportedCode, errText: "unsupported SGR code: 6, 48:5:1",
+		},
+		"colon after reset": {
+			give: "\x1b[1;0;58:2::1:2:3m", wantErr: colors.ErrUnsupportedCode, errText: "unsupported SGR code: 58:2::1:2:3",
+		},
+		"bad sub parameter": {give: "\x1b[4:xm", wantErr: colors.ErrInvalidSGR},
+		"negative":          {give: "-1", wantErr: colors.ErrInvalidSGR},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := colors.ParseStyle(tt.give)
+
+			assertTrue(t, errors.Is(err, tt.wantErr))
+			assertEqualValues(t, tt.want, got)
+
+			if tt.errText != "" {
+				assertEqualValues(t, tt.errText, err.Error())
+			}
+		})
+	}
+}
+
+func TestStyleFromCodes(t *testing.T) {
+	got, err := colors.StyleFromCodes()
+
+	assertEqualValues(t, nil, err)
+	assertEqualValues(t, colors.Reset, got)
+
+	got, err = colors.StyleFromCodes(3, 96, 101)
+
+	assertEqualValues(t, nil, err)
+	assertEqualValues(t, colors.Italic|colors.FgCyan|colors.FgBright|colors.BgRed|colors.BgBright, got)
+}
+
+func TestStyleFromCodesOK(t *testing.T) {
+	got, ok := colors.StyleFromCodesOK()
+
+	assertTrue(t, ok)
+	assertEqualValues(t, colors.Reset, got)
+
+	got, ok = colors.StyleFromCodesOK(1, 38, 5, 196, 44)
+
+	assertFalse(t, ok)
+	assertEqualValues(t, colors.Bold|colors.BgBlue, got)
+
+	got, ok = colors.StyleFromCodesOK(1, 0)
+
+	assertTrue(t, ok)
+	assertEqualValues(t, colors.Reset, got)
+
+	var codes = []int{1, 38, 2, 1, 2, 3, 6, 31}
+
+	if allocs := testing.AllocsPerRun(100, func() { _, _ = colors.StyleFromCodesOK(codes...) }); allocs > 0 {
+		t.Errorf("expected no allocations, got %v", allocs)
+	}
+}
+
+func TestTextStyle_ApplyCodes(t *testing.T) {
+	got, err := (colors.Bold | colors.Italic | colors.FgRed).ApplyCodes(22, 44, 92)
+
+	assertEqualValues(t, nil, err)
+	assertEqualValues(t, colors.Italic|colors.FgGreen|colors.FgBright|colors.BgBlue, got)
+
+	got, err = (colors.Bold | colors.FgRed).ApplyCodes(0)
+
+	assertEqualValues(t, nil, err)
+	assertEqualValues(t, colors.TextStyle(0), got)
+
+	got, err = colors.Underline.ApplyCodes(39, 48, 5, 1, 24)
+
+	assertTrue(t, errors.Is(err, colors.ErrUnsupportedCode))
+	assertEqualValues(t, colors.FgDefault, got)
+}
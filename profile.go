@@ -0,0 +1,74 @@
+package colors
+
+import (
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// Profile is a terminal color profile (the color depth, supported by the terminal).
+type Profile uint32
+
+const (
+	ProfileNone      Profile = iota // No colors
+	Profile16                       // 16 colors (4-bit ANSI colors)
+	Profile256                      // 256 colors (8-bit, xterm palette)
+	ProfileTrueColor                // 16 million colors (24-bit RGB)
+)
+
+// String returns a human-readable profile name.
+func (p Profile) String() string {
+	switch p {
+	case ProfileNone:
+		return "none"
+	case Profile16:
+		return "16"
+	case Profile256:
+		return "256"
+	case ProfileTrueColor:
+		return "truecolor"
+	}
+
+	return "unknown"
+}
+
+var colorsProfile = uint32(initColorsProfile()) //nolint:gochecknoglobals // atomic usage only
+
+// initColorsProfile returns initialization value for the color profile.
+func initColorsProfile() Profile {
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return ProfileTrueColor
+	}
+
+	var term = strings.ToLower(os.Getenv("TERM"))
+
+	switch {
+	case term == "dumb":
+		return ProfileNone
+	case strings.Contains(term, "truecolor"), strings.Contains(term, "24bit"), strings.HasSuffix(term, "-direct"):
+		return ProfileTrueColor
+	case os.Getenv("WT_SESSION") != "": // Windows Terminal
+		return ProfileTrueColor
+	case strings.Contains(term, "256color"):
+		return Profile256
+	}
+
+	if ci, isCI := DetectCI(); isCI && ci.ColorsSupported() {
+		return ci.Profile
+	}
+
+	return Profile16
+}
+
+// CurrentProfile returns the current color profile, used to render the RGB colors (see Color.Fg and Color.Bg). Also,
+// you can set a new profile. Note: the colors enabled state (see Enabled) is not affected by the profile.
+func CurrentProfile(newProfile ...Profile) Profile {
+	if len(newProfile) == 0 {
+		return Profile(atomic.LoadUint32(&colorsProfile))
+	}
+
+	atomic.StoreUint32(&colorsProfile, uint32(newProfile[0]))
+
+	return newProfile[0]
+}
@@ -0,0 +1,99 @@
+package progress
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gh.tarampamp.am/colors"
+)
+
+// Multi is a group of progress bars, rendered one under another. In terminal mode the whole group is redrawn in
+// place on every update. The Multi methods (and the bars methods) are goroutine-safe.
+//
+//	var m = progress.NewMulti(os.Stderr)
+//
+//	for _, f := range files {
+//		var bar = m.AddBar(f.Size)
+//
+//		bar.Label = f.Name
+//
+//		go download(f, bar)
+//	}
+type Multi struct {
+	// Colors enables the bars styles (the bars Colors field is ignored). NewMulti sets it using the
+	// colors.ColorModeAuto detection for the writer.
+	Colors bool
+
+	// Terminal enables in place redrawing (the bars Terminal field is ignored). NewMulti sets it to true when the
+	// writer is a terminal (see colors.IsTerminal).
+	Terminal bool
+
+	mu    sync.Mutex
+	w     io.Writer
+	bars  []*Bar
+	drawn int // the number of drawn lines (terminal mode)
+}
+
+// NewMulti creates a new progress bars group, that writes into the writer.
+func NewMulti(w io.Writer) *Multi {
+	return &Multi{w: w, Colors: colors.ColorModeAuto.Enabled(w), Terminal: colors.IsTerminal(w)}
+}
+
+// AddBar adds a new progress bar to the group (see NewBar).
+func (m *Multi) AddBar(total int64) *Bar {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var bar = &Bar{w: m.w, total: total, multi: m, Colors: m.Colors}
+
+	m.bars = append(m.bars, bar)
+
+	return bar
+}
+
+// Finish finishes all the bars in the group (see Bar.Finish).
+func (m *Multi) Finish() {
+	m.mu.Lock()
+	var bars = append([]*Bar(nil), m.bars...)
+	m.mu.Unlock()
+
+	for _, bar := range bars {
+		bar.Finish()
+	}
+}
+
+// refresh redraws the group, or writes the bar plain-text progress line.
+func (m *Multi) refresh(b *Bar, force bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.Terminal {
+		b.mu.Lock()
+		var line, ok = b.plainLine(force)
+		b.mu.Unlock()
+
+		if ok {
+			_, _ = io.WriteString(m.w, line+"\n")
+		}
+
+		return
+	}
+
+	var buf strings.Builder
+
+	if m.drawn > 0 { // move the cursor up, to the first bar line
+		buf.WriteString("\x1b[" + strconv.Itoa(m.drawn) + "A")
+	}
+
+	for _, bar := range m.bars {
+		bar.mu.Lock()
+		buf.WriteString("\r" + bar.line(m.Colors) + eraseLine + "\n")
+		bar.mu.Unlock()
+	}
+
+	m.drawn = len(m.bars)
+
+	_, _ = io.WriteString(m.w, buf.String())
+}
@@ -0,0 +1,278 @@
+// Package progress provides progress bars and spinners, styled with the colors.TextStyle. When the output is a
+// terminal, they are redrawn in place (using the carriage return and erase-line sequences), otherwise plain-text
+// progress lines are written periodically (e.g. for the CI logs or redirected output).
+package progress
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gh.tarampamp.am/colors"
+)
+
+const (
+	eraseLine = "\x1b[K" // erase from the cursor to the end of the line
+
+	defaultBarWidth      = 40
+	defaultPlainInterval = 5 * time.Second
+)
+
+// Bar is a progress bar. Exported fields are the bar settings, and should not be changed after the first update.
+// The bar methods are goroutine-safe.
+//
+//	var bar = progress.NewBar(os.Stderr, int64(len(files)))
+//
+//	bar.Label = "uploading"
+//	bar.FilledStyle = colors.FgGreen
+//
+//	for _, f := range files {
+//		upload(f)
+//		bar.Add(1)
+//	}
+//
+//	bar.Finish()
+type Bar struct {
+	Label         string           // Label, rendered before the bar
+	Width         int              // Bar width (in terminal cells), 40 by default
+	Filled, Empty string           // Filled and empty bar parts characters ("█" and "░" by default)
+	FilledStyle   colors.TextStyle // Filled bar part style
+	EmptyStyle    colors.TextStyle // Empty bar part style
+	LabelStyle    colors.TextStyle // Label style
+
+	// Colors enables the styles. NewBar sets it using the colors.ColorModeAuto detection for the writer (not for the
+	// os.Stdout, as the global colors state does).
+	Colors bool
+
+	// Terminal enables in place redrawing. NewBar sets it to true when the writer is a terminal (see
+	// colors.IsTerminal). When false, plain-text progress lines are written every PlainInterval.
+	Terminal      bool
+	PlainInterval time.Duration // Minimal interval between plain-text progress lines, 5 seconds by default
+
+	mu             sync.Mutex
+	w              io.Writer
+	multi          *Multi
+	total, current int64
+	drawn          string    // the last drawn line (terminal mode)
+	printed        time.Time // the last plain-text line time
+	printedLine    string    // the last plain-text line
+	finished       bool
+}
+
+// NewBar creates a new progress bar, that writes into the writer. The total may be zero or negative, if it's
+// unknown (only the current value is rendered in this case).
+func NewBar(w io.Writer, total int64) *Bar {
+	return &Bar{w: w, total: total, Colors: colors.ColorModeAuto.Enabled(w), Terminal: colors.IsTerminal(w)}
+}
+
+// Add increments the current progress value.
+func (b *Bar) Add(n int64) {
+	b.mu.Lock()
+	b.current += n
+	b.mu.Unlock()
+
+	b.refresh(false)
+}
+
+// Set sets the current progress value.
+func (b *Bar) Set(n int64) {
+	b.mu.Lock()
+	b.current = n
+	b.mu.Unlock()
+
+	b.refresh(false)
+}
+
+// SetTotal changes the total progress value.
+func (b *Bar) SetTotal(n int64) {
+	b.mu.Lock()
+	b.total = n
+	b.mu.Unlock()
+
+	b.refresh(false)
+}
+
+// Current returns the current progress value.
+func (b *Bar) Current() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.current
+}
+
+// Finish draws the bar for the last time. In terminal mode, the line break is written after the bar. Further
+// updates are ignored.
+func (b *Bar) Finish() {
+	b.mu.Lock()
+
+	if b.finished {
+		b.mu.Unlock()
+
+		return
+	}
+
+	b.finished = true
+	b.mu.Unlock()
+
+	b.refresh(true)
+}
+
+// String returns the rendered bar line (without the line break).
+func (b *Bar) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.line(b.Colors)
+}
+
+// refresh redraws the bar (or writes the plain-text progress line). If the bar belongs to the Multi, the whole
+// group is redrawn.
+func (b *Bar) refresh(force bool) {
+	if b.multi != nil {
+		b.multi.refresh(b, force)
+
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.Terminal {
+		if line, ok := b.plainLine(force); ok {
+			_, _ = io.WriteString(b.w, line+"\n")
+		}
+
+		return
+	}
+
+	if b.finished && !force {
+		return
+	}
+
+	var line = b.line(b.Colors)
+
+	if line != b.drawn {
+		b.drawn = line
+
+		_, _ = io.WriteString(b.w, "\r"+line+eraseLine)
+	}
+
+	if force {
+		_, _ = io.WriteString(b.w, "\n")
+	}
+}
+
+// percent returns the current progress in percents (or -1 if the total is unknown).
+func (b *Bar) percent() int {
+	if b.total <= 0 {
+		return -1
+	}
+
+	return int(min(max(b.current, 0), b.total) * 100 / b.total) //nolint:mnd
+}
+
+// counter returns the current (and total, if known) progress values.
+func (b *Bar) counter() string {
+	if b.total <= 0 {
+		return strconv.FormatInt(b.current, 10)
+	}
+
+	return strconv.FormatInt(b.current, 10) + "/" + strconv.FormatInt(b.total, 10)
+}
+
+// line returns the bar line, styled if colors are enabled. The mutex must be locked.
+func (b *Bar) line(enabled bool) string {
+	var (
+		buf     strings.Builder
+		percent = b.percent()
+		width   = b.Width
+	)
+
+	if width <= 0 {
+		width = defaultBarWidth
+	}
+
+	if b.Label != "" {
+		buf.WriteString(b.LabelStyle.WrapIf(enabled, b.Label))
+		buf.WriteByte(' ')
+	}
+
+	if percent >= 0 {
+		var (
+			filled, empty = b.Filled, b.Empty
+			n             = width * percent / 100 //nolint:mnd
+		)
+
+		if filled == "" {
+			filled = "█"
+		}
+
+		if empty == "" {
+			empty = "░"
+		}
+
+		if n > 0 {
+			buf.WriteString(b.FilledStyle.WrapIf(enabled, strings.Repeat(filled, n)))
+		}
+
+		if n < width {
+			buf.WriteString(b.EmptyStyle.WrapIf(enabled, strings.Repeat(empty, width-n)))
+		}
+
+		buf.WriteByte(' ')
+
+		var pct = strconv.Itoa(percent)
+
+		buf.WriteString(strings.Repeat(" ", 3-len(pct)) + pct + "% ") //nolint:mnd // right-aligned to 3 chars
+	}
+
+	buf.WriteString(b.counter())
+
+	return buf.String()
+}
+
+// plainLine returns the plain-text progress line, if it's time to write it (the first line, the PlainInterval is
+// elapsed, or the force flag is set). The forced line is skipped, if it's the same as the last written one. The mutex
+// must be locked.
+func (b *Bar) plainLine(force bool) (string, bool) {
+	if !force && (b.finished || (!b.printed.IsZero() && time.Since(b.printed) < b.plainInterval())) {
+		return "", false
+	}
+
+	var buf strings.Builder
+
+	if b.Label != "" {
+		buf.WriteString(b.Label)
+		buf.WriteString(": ")
+	}
+
+	if percent := b.percent(); percent >= 0 {
+		buf.WriteString(strconv.Itoa(percent))
+		buf.WriteString("% (")
+		buf.WriteString(b.counter())
+		buf.WriteByte(')')
+	} else {
+		buf.WriteString(b.counter())
+	}
+
+	var line = buf.String()
+
+	if force && line == b.printedLine {
+		return "", false
+	}
+
+	b.printed, b.printedLine = time.Now(), line
+
+	return line, true
+}
+
+// plainInterval returns the minimal interval between plain-text progress lines.
+func (b *Bar) plainInterval() time.Duration {
+	if b.PlainInterval > 0 {
+		return b.PlainInterval
+	}
+
+	return defaultPlainInterval
+}
@@ -0,0 +1,219 @@
+package progress_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gh.tarampamp.am/colors"
+	"gh.tarampamp.am/colors/colorstest"
+	"gh.tarampamp.am/colors/progress"
+)
+
+func ExampleBar() {
+	var bar = progress.NewBar(os.Stdout, 200)
+
+	bar.Label, bar.Width = "copying", 10
+	bar.Colors = false   // change to true to see colors
+	bar.Terminal = false // the output of the example is not a terminal
+
+	bar.Add(50) // the first progress line is written immediately
+	bar.Add(50) // skipped, since the plain-text lines interval is not elapsed
+	bar.Finish()
+
+	fmt.Println(bar)
+
+	// output:
+	// copying: 25% (50/200)
+	// copying: 50% (100/200)
+	// copying █████░░░░░  50% 100/200
+}
+
+func TestBar_String(t *testing.T) {
+	for name, tt := range map[string]struct {
+		giveTotal, giveCurrent int64
+		giveLabel              string
+		want                   string
+	}{
+		"empty":    {10, 0, "", "░░░░░   0% 0/10"},
+		"half":     {10, 5, "x", "x ██░░░  50% 5/10"},
+		"full":     {10, 10, "", "█████ 100% 10/10"},
+		"overflow": {10, 20, "", "█████ 100% 20/10"},
+		"negative": {10, -5, "", "░░░░░   0% -5/10"},
+		"no total": {0, 7, "files", "files 7"},
+		"quarter":  {4, 1, "", "█░░░░  25% 1/4"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bar = progress.NewBar(&bytes.Buffer{}, tt.giveTotal)
+
+			bar.Label, bar.Width = tt.giveLabel, 5
+			bar.Set(tt.giveCurrent)
+
+			if got := bar.String(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBar_Styled(t *testing.T) {
+	t.Setenv("FORCE_COLOR", "")
+	_ = os.Unsetenv("FORCE_COLOR") // restored by the t.Setenv cleanup
+	t.Setenv("NO_COLOR", "1")
+
+	var bar = progress.NewBar(&bytes.Buffer{}, 2)
+
+	if bar.Colors {
+		t.Fatal("colors are not detected for a buffer")
+	}
+
+	bar.Label, bar.Width, bar.Filled, bar.Empty = "x", 2, "#", "-"
+	bar.LabelStyle, bar.FilledStyle, bar.EmptyStyle = colors.Bold, colors.FgGreen, colors.Faint
+	bar.Set(1)
+
+	if got, want := bar.String(), "x #-  50% 1/2"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	bar.Colors = true
+
+	const want = "\x1b[1mx\x1b[22m \x1b[32m#\x1b[39m\x1b[2m-\x1b[22m  50% 1/2"
+
+	for _, global := range []bool{true, false} { // the global colors state does not matter
+		colorstest.Force(t, global)
+
+		if got := bar.String(); got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	}
+}
+
+func TestBar_Terminal(t *testing.T) {
+	var (
+		buf bytes.Buffer
+		bar = progress.NewBar(&buf, 4)
+	)
+
+	if bar.Terminal {
+		t.Fatal("buffer is not a terminal")
+	}
+
+	bar.Width, bar.Terminal = 4, true
+
+	bar.Add(1)
+	bar.Add(0) // not changed - not redrawn
+	bar.Add(1)
+	bar.Finish()
+	bar.Add(1) // ignored
+
+	const want = "\r█░░░  25% 1/4\x1b[K\r██░░  50% 2/4\x1b[K\n"
+
+	if got := buf.String(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestBar_Plain(t *testing.T) {
+	var (
+		buf bytes.Buffer
+		bar = progress.NewBar(&buf, 0)
+	)
+
+	bar.Label, bar.PlainInterval = "items", 20*time.Millisecond
+
+	bar.Add(1)
+	bar.Add(1)
+
+	time.Sleep(30 * time.Millisecond)
+
+	bar.Add(1)
+	bar.Finish()
+	bar.Finish()
+
+	const want = "items: 1\nitems: 3\n" // the final line is not repeated
+
+	if got := buf.String(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestBar_PlainFinish(t *testing.T) {
+	var (
+		buf bytes.Buffer
+		bar = progress.NewBar(&buf, 4)
+	)
+
+	bar.PlainInterval = time.Hour
+
+	bar.Add(1)
+	bar.Add(1) // skipped, the interval is not elapsed
+	bar.Finish()
+
+	const want = "25% (1/4)\n50% (2/4)\n" // the final line differs from the last written one
+
+	if got := buf.String(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestMulti(t *testing.T) {
+	var (
+		buf bytes.Buffer
+		m   = progress.NewMulti(&buf)
+	)
+
+	m.Terminal = true
+
+	var a, b = m.AddBar(2), m.AddBar(2)
+
+	a.Width, a.Label = 2, "a"
+	b.Width, b.Label = 2, "b"
+
+	a.Add(1)
+	b.Add(2)
+
+	const want = "" +
+		"\ra █░  50% 1/2\x1b[K\n\rb ░░   0% 0/2\x1b[K\n" +
+		"\x1b[2A\ra █░  50% 1/2\x1b[K\n\rb ██ 100% 2/2\x1b[K\n"
+
+	if got := buf.String(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestMulti_Concurrent(t *testing.T) {
+	var (
+		buf bytes.Buffer
+		m   = progress.NewMulti(&buf)
+		wg  sync.WaitGroup
+	)
+
+	for i := 0; i < 10; i++ {
+		var bar = m.AddBar(100)
+
+		bar.Label = fmt.Sprintf("bar%d", i)
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				bar.Add(1)
+			}
+		}()
+	}
+
+	wg.Wait()
+	m.Finish()
+
+	for i := 0; i < 10; i++ {
+		if want := fmt.Sprintf("bar%d: 100%% (100/100)\n", i); !strings.Contains(buf.String(), want) {
+			t.Errorf("expected %q in the output %q", want, buf.String())
+		}
+	}
+}
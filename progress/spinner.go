@@ -0,0 +1,159 @@
+package progress
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"gh.tarampamp.am/colors"
+)
+
+// Predefined spinner frames.
+var (
+	SpinnerDots = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"} //nolint:gochecknoglobals
+	SpinnerLine = []string{"|", "/", "-", "\\"}                              //nolint:gochecknoglobals
+)
+
+const defaultSpinnerInterval = 100 * time.Millisecond
+
+// Spinner is an activity indicator for the work of unknown duration. Exported fields are the spinner settings, and
+// should not be changed after the Start call. The spinner methods are goroutine-safe.
+//
+//	var s = progress.NewSpinner(os.Stderr, "connecting")
+//
+//	s.Start()
+//	defer s.Stop(colors.FgGreen.Wrap("connected"))
+type Spinner struct {
+	Frames     []string         // Animation frames, SpinnerDots by default
+	Interval   time.Duration    // Frames interval, 100ms by default
+	Style      colors.TextStyle // Frames style
+	LabelStyle colors.TextStyle // Label style
+
+	// Colors enables the styles. NewSpinner sets it using the colors.ColorModeAuto detection for the writer (not for
+	// the os.Stdout, as the global colors state does).
+	Colors bool
+
+	// Terminal enables the animation. NewSpinner sets it to true when the writer is a terminal (see
+	// colors.IsTerminal). When false, only the label changes and the final message are written (as plain-text lines).
+	Terminal bool
+
+	mu    sync.Mutex
+	w     io.Writer
+	label string
+	frame int
+	stop  chan struct{}
+	done  chan struct{}
+}
+
+// NewSpinner creates a new spinner with the label, that writes into the writer.
+func NewSpinner(w io.Writer, label string) *Spinner {
+	return &Spinner{w: w, label: label, Colors: colors.ColorModeAuto.Enabled(w), Terminal: colors.IsTerminal(w)}
+}
+
+// Start starts the spinner animation (in a separate goroutine). Does nothing if the spinner is already started.
+func (s *Spinner) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stop != nil {
+		return
+	}
+
+	if !s.Terminal {
+		_, _ = io.WriteString(s.w, s.label+"\n")
+
+		return
+	}
+
+	s.stop, s.done = make(chan struct{}), make(chan struct{})
+	s.draw()
+
+	go s.run(s.stop, s.done)
+}
+
+// run animates the spinner until the stop channel is closed.
+func (s *Spinner) run(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	var interval = s.Interval
+
+	if interval <= 0 {
+		interval = defaultSpinnerInterval
+	}
+
+	var ticker = time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			s.frame++
+			s.draw()
+			s.mu.Unlock()
+		}
+	}
+}
+
+// SetLabel changes the spinner label.
+func (s *Spinner) SetLabel(label string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if label == s.label {
+		return
+	}
+
+	s.label = label
+
+	switch {
+	case !s.Terminal:
+		_, _ = io.WriteString(s.w, label+"\n")
+	case s.stop != nil:
+		s.draw()
+	}
+}
+
+// Stop stops the spinner animation and erases the spinner line. The final message (if not empty) is written
+// instead of it, followed by the line break.
+func (s *Spinner) Stop(final string) {
+	s.mu.Lock()
+	var stop, done = s.stop, s.done
+	s.stop, s.done = nil, nil
+	s.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.Terminal {
+		_, _ = io.WriteString(s.w, "\r"+eraseLine)
+	}
+
+	if final != "" {
+		_, _ = io.WriteString(s.w, final+"\n")
+	}
+}
+
+// draw draws the current frame and the label. The mutex must be locked.
+func (s *Spinner) draw() {
+	var frames = s.Frames
+
+	if len(frames) == 0 {
+		frames = SpinnerDots
+	}
+
+	var line = "\r" + s.Style.WrapIf(s.Colors, frames[s.frame%len(frames)])
+
+	if s.label != "" {
+		line += " " + s.LabelStyle.WrapIf(s.Colors, s.label)
+	}
+
+	_, _ = io.WriteString(s.w, line+eraseLine)
+}
@@ -0,0 +1,102 @@
+package progress_test
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gh.tarampamp.am/colors"
+	"gh.tarampamp.am/colors/colorstest"
+	"gh.tarampamp.am/colors/progress"
+)
+
+// syncBuffer is a goroutine-safe bytes.Buffer.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+func TestSpinner_Terminal(t *testing.T) {
+	var (
+		buf syncBuffer
+		s   = progress.NewSpinner(&buf, "working")
+	)
+
+	s.Terminal, s.Frames, s.Interval = true, progress.SpinnerLine, time.Millisecond
+
+	s.Start()
+	s.Start() // no-op
+
+	time.Sleep(20 * time.Millisecond)
+
+	s.SetLabel("still working")
+	s.Stop("done")
+
+	var out = buf.String()
+
+	for _, want := range []string{"\r| working\x1b[K", "\r/ working\x1b[K", " still working\x1b[K", "\r\x1b[Kdone\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the output %q", want, out)
+		}
+	}
+
+	if !strings.HasSuffix(out, "\r\x1b[Kdone\n") {
+		t.Errorf("unexpected output ending: %q", out)
+	}
+}
+
+func TestSpinner_Plain(t *testing.T) {
+	var (
+		buf syncBuffer
+		s   = progress.NewSpinner(&buf, "working")
+	)
+
+	s.Start()
+	s.SetLabel("working")
+	s.SetLabel("almost done")
+	s.Stop("")
+	s.Stop("done")
+
+	const want = "working\nalmost done\ndone\n"
+
+	if got := buf.String(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestSpinner_Styled(t *testing.T) {
+	var (
+		buf syncBuffer
+		s   = progress.NewSpinner(&buf, "working")
+	)
+
+	s.Terminal, s.Colors, s.Interval = true, true, time.Hour
+	s.Style, s.LabelStyle = colors.FgRed, colors.Bold
+
+	colorstest.Force(t, false) // the global colors state does not matter
+
+	s.Start()
+	s.Stop("")
+
+	const want = "\r\x1b[31m⠋\x1b[39m \x1b[1mworking\x1b[22m\x1b[K\r\x1b[K"
+
+	if got := buf.String(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,254 @@
+package colors
+
+import (
+	"strings"
+)
+
+// Align is a text alignment.
+type Align uint8
+
+const (
+	AlignLeft   Align = iota // Align to the left
+	AlignRight               // Align to the right
+	AlignCenter              // Align to the center
+)
+
+// pad pads the string with spaces to fit the width (in terminal cells), according to the alignment.
+func (a Align) pad(s string, width int) string {
+	var gap = width - VisibleWidth(s)
+
+	if gap <= 0 {
+		return s
+	}
+
+	switch a {
+	case AlignRight:
+		return strings.Repeat(" ", gap) + s
+	case AlignCenter:
+		return strings.Repeat(" ", gap/2) + s + strings.Repeat(" ", gap-gap/2) //nolint:mnd
+	}
+
+	return s + strings.Repeat(" ", gap)
+}
+
+// Table is a table renderer. Cells may contain styled text (escape sequences) and wide characters - columns are
+// aligned by the visible width (see VisibleWidth).
+//
+//	var t = colors.Table{Header: []string{"Name", "Status"}, Border: colors.BorderRounded, HeaderStyle: colors.Bold}
+//
+//	t.AddRow("api", colors.FgGreen.Wrap("running"))
+//	t.AddRow("db", colors.FgRed.Wrap("stopped"))
+//
+//	fmt.Print(t.Render())
+type Table struct {
+	Header      []string   // Header cells (optional)
+	Rows        [][]string // Rows cells (rows may have different lengths)
+	Align       []Align    // Per-column alignment (AlignLeft for the missing ones)
+	Border      Border     // Border characters set (BorderNone by default)
+	BorderStyle TextStyle  // Border style
+	HeaderStyle TextStyle  // Header cells style
+	RowStyle    TextStyle  // Rows cells style
+	AltRowStyle TextStyle  // Every second row cells style (zebra striping), RowStyle is used if zero
+	MaxWidth    int        // Maximal table width (e.g. the terminal width, see TerminalWidth), 0 for unlimited
+	Wrap        bool       // Wrap cells content to fit the MaxWidth (the content is truncated otherwise)
+}
+
+// AddRow appends a row to the table.
+func (t *Table) AddRow(cells ...string) { t.Rows = append(t.Rows, cells) }
+
+// columns returns the number of table columns.
+func (t *Table) columns() (n int) {
+	n = len(t.Header)
+
+	for _, row := range t.Rows {
+		n = max(n, len(row))
+	}
+
+	return n
+}
+
+// overhead returns the number of cells, used by the borders and paddings.
+func (t *Table) overhead(columns int) int {
+	if t.Border.Vertical == "" {
+		return 2 * (columns - 1) //nolint:mnd // columns are separated with two spaces
+	}
+
+	return 3*columns + 1 //nolint:mnd // "| " + " " for each column, and the right border
+}
+
+// widths returns the columns widths, shrunk to fit the MaxWidth.
+func (t *Table) widths(columns int) []int {
+	var widths = make([]int, columns)
+
+	for i, cell := range t.Header {
+		widths[i] = VisibleWidth(cell)
+	}
+
+	for _, row := range t.Rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], VisibleWidth(cell))
+		}
+	}
+
+	if t.MaxWidth <= 0 {
+		return widths
+	}
+
+	var total = t.overhead(columns)
+
+	for _, w := range widths {
+		total += w
+	}
+
+	for total > t.MaxWidth { // shrink the widest column one by one
+		var widest int
+
+		for i, w := range widths {
+			if w > widths[widest] {
+				widest = i
+			}
+		}
+
+		if widths[widest] <= 1 {
+			break // cannot shrink anymore
+		}
+
+		widths[widest]--
+		total--
+	}
+
+	return widths
+}
+
+// Render renders the table. Every line ends with a line break. The styles are not applied when colors are disabled.
+func (t *Table) Render() string { return t.RenderIf(Enabled()) }
+
+// RenderIf is the same as Render, but uses the provided colors state instead of the global one.
+func (t *Table) RenderIf(enabled bool) string {
+	var columns = t.columns()
+
+	if columns == 0 {
+		return ""
+	}
+
+	var (
+		widths = t.widths(columns)
+		buf    strings.Builder
+		outer  = make([]int, columns) // columns widths including paddings
+	)
+
+	for i, w := range widths {
+		outer[i] = w + 2 //nolint:mnd
+	}
+
+	var writeLine = func(line string) {
+		if line != "" {
+			buf.WriteString(t.BorderStyle.WrapIf(enabled, line))
+			buf.WriteByte('\n')
+		}
+	}
+
+	writeLine(t.Border.line(outer, t.Border.TopLeft, t.Border.TopMid, t.Border.TopRight))
+
+	if len(t.Header) > 0 {
+		t.writeRow(&buf, t.Header, widths, t.HeaderStyle, enabled)
+		writeLine(t.Border.line(outer, t.Border.MidLeft, t.Border.Cross, t.Border.MidRight))
+	}
+
+	for i, row := range t.Rows {
+		var style = t.RowStyle
+
+		if i%2 == 1 && t.AltRowStyle != 0 {
+			style = t.AltRowStyle
+		}
+
+		t.writeRow(&buf, row, widths, style, enabled)
+	}
+
+	writeLine(t.Border.line(outer, t.Border.BottomLeft, t.Border.BottomMid, t.Border.BottomRight))
+
+	return buf.String()
+}
+
+// String returns the rendered table (see Render).
+func (t *Table) String() string { return t.Render() }
+
+// cellLines splits the cell content into lines, that fit the width (wrapping or truncating them).
+func (t *Table) cellLines(cell string, width int) []string {
+	if t.Wrap {
+		return WordWrap(cell, width)
+	}
+
+	var lines = strings.Split(cell, "\n")
+
+	for i, line := range lines {
+		lines[i] = Truncate(line, width, "…")
+	}
+
+	return lines
+}
+
+// writeRow writes the (possibly multi-line) row.
+func (t *Table) writeRow(buf *strings.Builder, row []string, widths []int, style TextStyle, enabled bool) {
+	var (
+		cells  = make([][]string, len(widths))
+		height = 1
+	)
+
+	for i := range widths {
+		var cell string
+
+		if i < len(row) {
+			cell = row[i]
+		}
+
+		cells[i] = t.cellLines(cell, widths[i])
+		height = max(height, len(cells[i]))
+	}
+
+	var vertical = t.BorderStyle.WrapIf(enabled, t.Border.Vertical)
+
+	for ln := 0; ln < height; ln++ {
+		var line strings.Builder
+
+		for i, w := range widths {
+			var (
+				content string
+				align   = AlignLeft
+			)
+
+			if ln < len(cells[i]) {
+				content = cells[i][ln]
+			}
+
+			if i < len(t.Align) {
+				align = t.Align[i]
+			}
+
+			if t.Border.Vertical == "" {
+				if i > 0 {
+					line.WriteString("  ")
+				}
+
+				line.WriteString(style.WrapIf(enabled, keepStyle(align.pad(content, w), style, enabled)))
+
+				continue
+			}
+
+			line.WriteString(vertical)
+			line.WriteString(style.WrapIf(enabled, " "+keepStyle(align.pad(content, w), style, enabled)+" "))
+		}
+
+		if t.Border.Vertical != "" {
+			line.WriteString(vertical)
+		}
+
+		if t.Border.Vertical == "" && style == 0 {
+			buf.WriteString(strings.TrimRight(line.String(), " ")) // no trailing spaces
+		} else {
+			buf.WriteString(line.String())
+		}
+
+		buf.WriteByte('\n')
+	}
+}
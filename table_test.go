@@ -0,0 +1,173 @@
+package colors_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"gh.tarampamp.am/colors"
+)
+
+func ExampleTable() {
+	colors.Enabled(false) // change to true to see colors
+
+	var t = colors.Table{
+		Header:      []string{"Name", "Status", "CPU"},
+		Align:       []colors.Align{colors.AlignLeft, colors.AlignCenter, colors.AlignRight},
+		Border:      colors.BorderRounded,
+		HeaderStyle: colors.Bold,
+	}
+
+	t.AddRow("api", colors.FgGreen.Wrap("running"), "12%")
+	t.AddRow("database", colors.FgRed.Wrap("stopped"), "0%")
+
+	fmt.Print(t.Render())
+
+	// output:
+	// ╭──────────┬─────────┬─────╮
+	// │ Name     │ Status  │ CPU │
+	// ├──────────┼─────────┼─────┤
+	// │ api      │ running │ 12% │
+	// │ database │ stopped │  0% │
+	// ╰──────────┴─────────┴─────╯
+}
+
+func TestTable_Render(t *testing.T) {
+	var colorsState = colors.Enabled()
+
+	defer colors.Enabled(colorsState)
+
+	colors.Enabled(false)
+
+	for name, tt := range map[string]struct {
+		giveTable colors.Table
+		want      string
+	}{
+		"empty": {colors.Table{}, ""},
+		"no borders": {
+			colors.Table{Header: []string{"a", "bb"}, Rows: [][]string{{"ccc", "d"}, {"e"}}},
+			"a    bb\nccc  d\ne\n",
+		},
+		"ascii": {
+			colors.Table{Rows: [][]string{{"a", "b"}}, Border: colors.BorderASCII},
+			"+---+---+\n| a | b |\n+---+---+\n",
+		},
+		"heavy with multiline cells": {
+			colors.Table{Rows: [][]string{{"a\nb", "c"}}, Border: colors.BorderHeavy},
+			"┏━━━┳━━━┓\n┃ a ┃ c ┃\n┃ b ┃   ┃\n┗━━━┻━━━┛\n",
+		},
+		"wide characters": {
+			colors.Table{Rows: [][]string{{"日本"}, {"abc"}}, Border: colors.BorderLight},
+			"┌──────┐\n│ 日本 │\n│ abc  │\n└──────┘\n",
+		},
+		"truncated": {
+			colors.Table{Rows: [][]string{{"foobarbaz", "x"}}, Border: colors.BorderASCII, MaxWidth: 12},
+			"+------+---+\n| foo… | x |\n+------+---+\n",
+		},
+		"wrapped": {
+			colors.Table{Rows: [][]string{{"foo bar", "x"}}, Border: colors.BorderASCII, MaxWidth: 12, Wrap: true},
+			"+------+---+\n| foo  | x |\n| bar  |   |\n+------+---+\n",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assertEqualValues(t, tt.want, tt.giveTable.Render())
+			assertEqualValues(t, tt.want, tt.giveTable.String())
+		})
+	}
+}
+
+func TestTable_RenderStyled(t *testing.T) {
+	var colorsState = colors.Enabled()
+
+	defer colors.Enabled(colorsState)
+
+	colors.Enabled(true)
+
+	var table = colors.Table{
+		Header:      []string{"Name", "Value"},
+		Border:      colors.BorderLight,
+		BorderStyle: colors.FgBlue,
+		HeaderStyle: colors.Bold,
+		RowStyle:    colors.BgBlack,
+		AltRowStyle: colors.BgWhite,
+		MaxWidth:    20,
+	}
+
+	table.AddRow(colors.FgRed.Wrap("a very long styled value"), "1")
+	table.AddRow("b", "2")
+	table.AddRow("c", "3")
+
+	var (
+		out   = table.Render()
+		lines = strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	)
+
+	assertEqualValues(t, 7, len(lines))
+
+	for _, line := range lines {
+		assertEqualValues(t, 20, colors.VisibleWidth(line)) // all lines are aligned
+	}
+
+	assertTrue(t, strings.Contains(out, "\x1b[1m Name"))
+	assertTrue(t, strings.Contains(out, "\x1b[47m b"))
+	assertTrue(t, strings.Contains(out, "\x1b[47m 2"))
+	assertTrue(t, strings.Contains(out, "\x1b[40m c"))
+	assertTrue(t, strings.Contains(out, "…\x1b[39m")) // the cell style is reset after the truncation
+	assertTrue(t, strings.HasPrefix(out, "\x1b[34m┌"))
+}
+
+func TestTable_RenderStyledNoBorder(t *testing.T) {
+	var colorsState = colors.Enabled()
+
+	defer colors.Enabled(colorsState)
+
+	colors.Enabled(true)
+
+	var table = colors.Table{Header: []string{"A"}, Border: colors.BorderNone, BorderStyle: colors.FgRed}
+
+	table.AddRow("x")
+
+	assertEqualValues(t, "A\nx\n", table.Render()) // no empty border sequences
+}
+
+func TestTable_RenderWrappedStyledCell(t *testing.T) {
+	var colorsState = colors.Enabled()
+
+	defer colors.Enabled(colorsState)
+
+	colors.Enabled(true)
+
+	var table = colors.Table{Border: colors.BorderASCII, RowStyle: colors.BgBlue, MaxWidth: 12, Wrap: true}
+
+	table.AddRow("id", colors.FgRed.Wrap("aaa bbb"))
+
+	assertEqualValues(t, "+----+-----+\n"+
+		"|\x1b[44m id \x1b[49m|\x1b[44m \x1b[31maaa\x1b[0m\x1b[44m \x1b[49m|\n"+ // the row style is kept after the reset
+		"|\x1b[44m    \x1b[49m|\x1b[44m \x1b[31mbbb\x1b[39m \x1b[49m|\n"+
+		"+----+-----+\n",
+		table.Render(),
+	)
+}
+
+func TestTable_RenderIf(t *testing.T) {
+	var colorsState = colors.Enabled()
+
+	defer colors.Enabled(colorsState)
+
+	var table = colors.Table{Header: []string{"A"}, Border: colors.BorderASCII, HeaderStyle: colors.Bold}
+
+	const (
+		plain  = "+---+\n| A |\n+---+\n+---+\n"
+		styled = "+---+\n|\x1b[1m A \x1b[22m|\n+---+\n+---+\n"
+	)
+
+	for _, global := range []bool{true, false} {
+		colors.Enabled(global)
+
+		assertEqualValues(t, styled, table.RenderIf(true))
+		assertEqualValues(t, plain, table.RenderIf(false))
+		assertEqualValues(t, styled, table.RenderContext(colors.WithEnabled(context.Background(), true)))
+		assertEqualValues(t, plain, table.RenderContext(colors.WithEnabled(context.Background(), false)))
+	}
+}
@@ -0,0 +1,204 @@
+package colors
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Tree is a tree node, rendered by the TreeRenderer (e.g. a dependency or a resource hierarchy).
+//
+//	var root = &colors.Tree{Label: "app"}
+//
+//	root.Add("api").Add("handlers")
+//	root.Add("db")
+//
+//	fmt.Print(root)
+type Tree struct {
+	Label     string    // Node label (may contain styled text and line breaks)
+	Style     TextStyle // Node label style (overrides the renderer depth styles if not zero)
+	Collapsed bool      // Do not render the node children (the number of hidden nodes is rendered instead)
+	Children  []*Tree   // Child nodes
+}
+
+// Add appends a child node with the label and returns it.
+func (t *Tree) Add(label string) *Tree {
+	var child = &Tree{Label: label}
+
+	t.Children = append(t.Children, child)
+
+	return child
+}
+
+// size returns the number of the node descendants.
+func (t *Tree) size() (n int) {
+	for _, child := range t.Children {
+		n += 1 + child.size()
+	}
+
+	return n
+}
+
+// String renders the tree using the default renderer.
+func (t *Tree) String() string { return TreeRenderer{}.Render(t) }
+
+// TreeGuides is a set of the tree guides (the lines, connecting the nodes). All the guides should have the same
+// visible width.
+type TreeGuides struct {
+	Branch   string // Before the node, that has the next siblings
+	Last     string // Before the last node
+	Vertical string // Before the descendants of the node, that has the next siblings
+	Space    string // Before the descendants of the last node
+}
+
+// Predefined tree guides.
+var (
+	TreeGuidesUnicode = TreeGuides{"├── ", "└── ", "│   ", "    "} //nolint:gochecknoglobals
+	TreeGuidesASCII   = TreeGuides{"|-- ", "`-- ", "|   ", "    "} //nolint:gochecknoglobals
+)
+
+// ellipsis returns the ellipsis, matching the guides charset (ASCII or Unicode).
+func (g TreeGuides) ellipsis() string {
+	for _, s := range [...]string{g.Branch, g.Last, g.Vertical, g.Space} {
+		for i := 0; i < len(s); i++ {
+			if s[i] >= utf8.RuneSelf {
+				return "…"
+			}
+		}
+	}
+
+	return "..."
+}
+
+// TreeRenderer renders trees.
+//
+//	var r = colors.TreeRenderer{GuideStyle: colors.Faint, DepthStyles: []colors.TextStyle{colors.Bold, colors.FgBlue}}
+//
+//	fmt.Print(r.Render(root))
+type TreeRenderer struct {
+	// Guides is a set of the tree guides. If zero, TreeGuidesUnicode is used when the locale is UTF-8 capable (see
+	// the LC_ALL, LC_CTYPE and LANG environment variables), and TreeGuidesASCII otherwise.
+	Guides TreeGuides
+
+	GuideStyle     TextStyle   // Guides style
+	DepthStyles    []TextStyle // Labels styles per depth (the root has depth 0), repeated when the tree is deeper
+	CollapsedStyle TextStyle   // Style of the hidden nodes number (see MaxDepth and Tree.Collapsed)
+	MaxDepth       int         // Maximal rendered depth (deeper levels are collapsed), 0 for unlimited
+}
+
+// utf8Locale returns true if the locale (from the environment) uses the UTF-8 encoding.
+func utf8Locale() bool {
+	for _, name := range [...]string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if value := strings.ToLower(os.Getenv(name)); value != "" {
+			return strings.Contains(value, "utf-8") || strings.Contains(value, "utf8")
+		}
+	}
+
+	return false
+}
+
+// guides returns the guides to use.
+func (r TreeRenderer) guides() TreeGuides {
+	if r.Guides != (TreeGuides{}) {
+		return r.Guides
+	}
+
+	if utf8Locale() {
+		return TreeGuidesUnicode
+	}
+
+	return TreeGuidesASCII
+}
+
+// labelStyle returns the label style for the node on the given depth.
+func (r TreeRenderer) labelStyle(node *Tree, depth int) TextStyle {
+	if node.Style != 0 || len(r.DepthStyles) == 0 {
+		return node.Style
+	}
+
+	return r.DepthStyles[depth%len(r.DepthStyles)]
+}
+
+// Render renders the tree. If the root label is empty, the root line is omitted (the children are rendered as
+// a forest). Every line ends with a line break. The styles are not applied when colors are disabled.
+func (r TreeRenderer) Render(root *Tree) string { return r.RenderIf(Enabled(), root) }
+
+// RenderIf is the same as Render, but uses the provided colors state instead of the global one.
+func (r TreeRenderer) RenderIf(enabled bool, root *Tree) string {
+	if root == nil {
+		return ""
+	}
+
+	var tw = treeWriter{TreeRenderer: r, buf: new(strings.Builder), guides: r.guides(), enabled: enabled}
+
+	if root.Label == "" {
+		tw.writeChildren(root, "", 0)
+	} else {
+		tw.writeNode(root, "", "", 0)
+	}
+
+	return tw.buf.String()
+}
+
+// treeWriter writes the tree nodes, rendered by the TreeRenderer, into the buffer.
+type treeWriter struct {
+	TreeRenderer
+
+	buf     *strings.Builder
+	guides  TreeGuides
+	enabled bool // colors state
+}
+
+// writeNode writes the node label (prefixed with the guides) and its children. The first prefix is used for the
+// first label line, and the rest prefix - for the rest of the label lines and the children.
+func (w treeWriter) writeNode(node *Tree, first, rest string, depth int) {
+	var (
+		buf, guides = w.buf, w.guides
+		style       = w.labelStyle(node, depth)
+	)
+
+	for i, line := range strings.Split(node.Label, "\n") {
+		var prefix = first
+
+		if i > 0 {
+			prefix = rest
+
+			if len(node.Children) > 0 {
+				prefix += guides.Vertical
+			}
+		}
+
+		if prefix != "" {
+			buf.WriteString(w.GuideStyle.WrapIf(w.enabled, prefix))
+		}
+
+		buf.WriteString(style.WrapIf(w.enabled, line))
+		buf.WriteByte('\n')
+	}
+
+	if len(node.Children) == 0 {
+		return
+	}
+
+	if node.Collapsed || (w.MaxDepth > 0 && depth >= w.MaxDepth) {
+		buf.WriteString(w.GuideStyle.WrapIf(w.enabled, rest+guides.Last))
+		buf.WriteString(w.CollapsedStyle.WrapIf(w.enabled, guides.ellipsis()+" "+strconv.Itoa(node.size())+" more"))
+		buf.WriteByte('\n')
+
+		return
+	}
+
+	w.writeChildren(node, rest, depth+1)
+}
+
+// writeChildren writes the node children with the given prefix.
+func (w treeWriter) writeChildren(node *Tree, prefix string, depth int) {
+	for i, child := range node.Children {
+		if i == len(node.Children)-1 {
+			w.writeNode(child, prefix+w.guides.Last, prefix+w.guides.Space, depth)
+		} else {
+			w.writeNode(child, prefix+w.guides.Branch, prefix+w.guides.Vertical, depth)
+		}
+	}
+}
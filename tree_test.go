@@ -0,0 +1,185 @@
+package colors_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"gh.tarampamp.am/colors"
+)
+
+func ExampleTree() {
+	colors.Enabled(false) // change to true to see colors
+
+	var root = &colors.Tree{Label: "app"}
+
+	var api = root.Add("api")
+
+	api.Add("handlers")
+	api.Add("middleware")
+	root.Add("db").Add("migrations")
+
+	var r = colors.TreeRenderer{
+		Guides:      colors.TreeGuidesUnicode,
+		GuideStyle:  colors.Faint,
+		DepthStyles: []colors.TextStyle{colors.Bold, colors.FgBlue, 0},
+	}
+
+	fmt.Print(r.Render(root))
+
+	// output:
+	// app
+	// ├── api
+	// │   ├── handlers
+	// │   └── middleware
+	// └── db
+	//     └── migrations
+}
+
+func TestTreeRenderer_Render(t *testing.T) {
+	var colorsState = colors.Enabled()
+
+	defer colors.Enabled(colorsState)
+
+	colors.Enabled(false)
+
+	var newTree = func() *colors.Tree {
+		var root = &colors.Tree{Label: "root"}
+
+		var a = root.Add("a")
+
+		a.Add("a1").Add("a11")
+		a.Add("a2")
+		root.Add("b\nsecond line").Add("b1")
+
+		return root
+	}
+
+	for name, tt := range map[string]struct {
+		giveRenderer colors.TreeRenderer
+		giveTree     func() *colors.Tree
+		want         string
+	}{
+		"nil": {colors.TreeRenderer{}, func() *colors.Tree { return nil }, ""},
+		"single node": {
+			colors.TreeRenderer{}, func() *colors.Tree { return &colors.Tree{Label: "x"} }, "x\n",
+		},
+		"ascii": {
+			colors.TreeRenderer{Guides: colors.TreeGuidesASCII}, newTree,
+			"root\n|-- a\n|   |-- a1\n|   |   `-- a11\n|   `-- a2\n`-- b\n    |   second line\n    `-- b1\n",
+		},
+		"max depth": {
+			colors.TreeRenderer{Guides: colors.TreeGuidesUnicode, MaxDepth: 1}, newTree,
+			"root\n├── a\n│   └── … 3 more\n└── b\n    │   second line\n    └── … 1 more\n",
+		},
+		"collapsed node": {
+			colors.TreeRenderer{Guides: colors.TreeGuidesASCII}, func() *colors.Tree {
+				var root = newTree()
+
+				root.Children[0].Collapsed = true
+
+				return root
+			},
+			"root\n|-- a\n|   `-- ... 3 more\n`-- b\n    |   second line\n    `-- b1\n",
+		},
+		"forest": {
+			colors.TreeRenderer{Guides: colors.TreeGuidesASCII}, func() *colors.Tree {
+				var root = &colors.Tree{}
+
+				root.Add("x")
+				root.Add("y").Add("z")
+
+				return root
+			},
+			"|-- x\n`-- y\n    `-- z\n",
+		},
+		"multi-line with children": {
+			colors.TreeRenderer{Guides: colors.TreeGuidesASCII}, func() *colors.Tree {
+				var root = &colors.Tree{Label: "x\ny"}
+
+				root.Add("z")
+
+				return root
+			},
+			"x\n|   y\n`-- z\n",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assertEqualValues(t, tt.want, tt.giveRenderer.Render(tt.giveTree()))
+		})
+	}
+}
+
+func TestTreeRenderer_RenderStyled(t *testing.T) {
+	var colorsState = colors.Enabled()
+
+	defer colors.Enabled(colorsState)
+
+	colors.Enabled(true)
+
+	var root = &colors.Tree{Label: "r"}
+
+	root.Add("a")
+	root.Add("b").Style = colors.FgRed
+
+	var r = colors.TreeRenderer{
+		Guides:      colors.TreeGuidesASCII,
+		GuideStyle:  colors.Faint,
+		DepthStyles: []colors.TextStyle{colors.Bold, colors.FgBlue},
+	}
+
+	assertEqualValues(t,
+		"\x1b[1mr\x1b[22m\n"+
+			"\x1b[2m|-- \x1b[22m\x1b[34ma\x1b[39m\n"+
+			"\x1b[2m`-- \x1b[22m\x1b[31mb\x1b[39m\n",
+		r.Render(root),
+	)
+}
+
+func TestTreeRenderer_Guides(t *testing.T) {
+	var root = &colors.Tree{Label: "r"}
+
+	root.Add("a")
+
+	for _, tt := range []struct {
+		giveLCAll, giveLCCType, giveLang string
+		want                             string
+	}{
+		{"", "", "en_US.UTF-8", "r\n└── a\n"},
+		{"", "en_US.utf8", "C", "r\n└── a\n"},
+		{"C", "", "en_US.UTF-8", "r\n`-- a\n"},
+		{"", "", "", "r\n`-- a\n"},
+	} {
+		t.Setenv("LC_ALL", tt.giveLCAll)
+		t.Setenv("LC_CTYPE", tt.giveLCCType)
+		t.Setenv("LANG", tt.giveLang)
+
+		assertEqualValues(t, tt.want, colors.TreeRenderer{}.Render(root))
+	}
+}
+
+func TestTreeRenderer_RenderIf(t *testing.T) {
+	var colorsState = colors.Enabled()
+
+	defer colors.Enabled(colorsState)
+
+	var root = &colors.Tree{Label: "r", Collapsed: true}
+
+	root.Add("a")
+
+	var r = colors.TreeRenderer{Guides: colors.TreeGuidesASCII, GuideStyle: colors.Faint, CollapsedStyle: colors.Italic}
+
+	const (
+		plain  = "r\n`-- ... 1 more\n"
+		styled = "r\n\x1b[2m`-- \x1b[22m\x1b[3m... 1 more\x1b[23m\n"
+	)
+
+	for _, global := range []bool{true, false} {
+		colors.Enabled(global)
+
+		assertEqualValues(t, styled, r.RenderIf(true, root))
+		assertEqualValues(t, plain, r.RenderIf(false, root))
+		assertEqualValues(t, styled, r.RenderContext(colors.WithEnabled(context.Background(), true), root))
+		assertEqualValues(t, plain, r.RenderContext(colors.WithEnabled(context.Background(), false), root))
+	}
+}
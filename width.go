@@ -0,0 +1,390 @@
+package colors
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"gh.tarampamp.am/colors/internal/grapheme"
+	"gh.tarampamp.am/colors/internal/termsize"
+)
+
+// escapeLen returns the length of the escape sequence at the beginning of the string (0 if the string does not start
+// with the escape character). CSI, OSC, DCS, APC, PM, SOS and the short (two-byte) escape sequences are recognized.
+func escapeLen(s string) int {
+	if len(s) == 0 || s[0] != '\x1b' {
+		return 0
+	}
+
+	if len(s) == 1 {
+		return 1
+	}
+
+	switch s[1] {
+	case '[': // CSI - parameters and intermediates, followed by the final byte (0x40..0x7e)
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7e {
+				return i + 1
+			}
+		}
+	case ']', 'P', '_', '^', 'X': // OSC, DCS, APC, PM, SOS - terminated by BEL or ST (ESC \)
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1
+			} else if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2 //nolint:mnd
+			}
+		}
+	default: // intermediates (0x20..0x2f), followed by the final byte
+		for i := 1; i < len(s); i++ {
+			if s[i] < 0x20 || s[i] > 0x2f {
+				return i + 1
+			}
+		}
+	}
+
+	return len(s) // unterminated sequence
+}
+
+// isSGR returns true if the escape sequence is the SGR (Select Graphic Rendition) one.
+func isSGR(seq string) bool { return len(seq) > 2 && seq[1] == '[' && seq[len(seq)-1] == 'm' }
+
+// StripANSI returns the string without escape sequences.
+func StripANSI(s string) string {
+	if strings.IndexByte(s, '\x1b') < 0 {
+		return s
+	}
+
+	var buf strings.Builder
+
+	buf.Grow(len(s))
+
+	for len(s) > 0 {
+		if n := escapeLen(s); n > 0 {
+			s = s[n:]
+
+			continue
+		}
+
+		var i = strings.IndexByte(s, '\x1b')
+		if i < 0 {
+			i = len(s)
+		}
+
+		buf.WriteString(s[:i])
+		s = s[i:]
+	}
+
+	return buf.String()
+}
+
+// VisibleWidth returns the number of terminal cells, used to render the string. Escape sequences are ignored, wide
+// (e.g. CJK) characters and emojis occupy two cells, combining marks - zero. For multi-line strings, the width of
+// the widest line is returned.
+func VisibleWidth(s string) (width int) {
+	var line int
+
+	for len(s) > 0 {
+		if n := escapeLen(s); n > 0 {
+			s = s[n:]
+
+			continue
+		}
+
+		if s[0] == '\n' {
+			width, line, s = max(width, line), 0, s[1:]
+
+			continue
+		}
+
+		var n = grapheme.Next(s)
+
+		line += grapheme.Width(s[:n])
+		s = s[n:]
+	}
+
+	return max(width, line)
+}
+
+// Truncate truncates the string to fit the width (in terminal cells, see VisibleWidth), appending the tail (e.g.
+// "…") when the string was truncated. Escape sequences are preserved (including the ones from the truncated part),
+// so the styles are reset correctly.
+func Truncate(s string, width int, tail string) string {
+	if VisibleWidth(s) <= width {
+		return s
+	}
+
+	var (
+		limit = width - VisibleWidth(tail)
+		buf   strings.Builder
+		w     int
+	)
+
+	if limit < 0 { // the tail does not fit
+		limit, tail = width, ""
+	}
+
+	buf.Grow(len(s) + len(tail))
+
+	for len(s) > 0 {
+		if n := escapeLen(s); n > 0 {
+			buf.WriteString(s[:n])
+			s = s[n:]
+
+			continue
+		}
+
+		var (
+			n  = grapheme.Next(s)
+			gw = grapheme.Width(s[:n])
+		)
+
+		if w+gw > limit {
+			break
+		}
+
+		buf.WriteString(s[:n])
+		s, w = s[n:], w+gw
+	}
+
+	buf.WriteString(tail)
+
+	for len(s) > 0 { // keep the escape sequences from the truncated part
+		if n := escapeLen(s); n > 0 {
+			buf.WriteString(s[:n])
+			s = s[n:]
+
+			continue
+		}
+
+		s = s[grapheme.Next(s):]
+	}
+
+	return buf.String()
+}
+
+// WordWrap wraps the string into lines, that fit the width (in terminal cells, see VisibleWidth). Lines are broken
+// at spaces, too long words are broken at any place. Existing line breaks are preserved. Every line is
+// self-contained: the active styles (SGR sequences) are reset at the end of the line and restored at the beginning
+// of the next one, so the lines can be printed separately (e.g. in a table cell or a box).
+func WordWrap(s string, width int) []string {
+	var w = wordWrapper{width: max(width, 1)}
+
+	for i, paragraph := range strings.Split(s, "\n") {
+		if i > 0 {
+			w.newLine()
+		}
+
+		w.paragraph(paragraph)
+	}
+
+	w.newLine()
+
+	return w.lines
+}
+
+// wordWrapper is a WordWrap state.
+type wordWrapper struct {
+	width  int
+	lines  []string
+	line   strings.Builder
+	lineW  int
+	fresh  bool     // the line was started by the paragraph (not by the wrapping)
+	state  sgrState // active styles
+	word   []wrapToken
+	wordW  int
+	spaces int // pending spaces count
+}
+
+// wrapToken is a word part - the grapheme cluster or the escape sequence.
+type wrapToken struct {
+	s     string
+	width int
+	esc   bool
+}
+
+// paragraph wraps the paragraph (a line without line breaks).
+func (w *wordWrapper) paragraph(s string) {
+	w.fresh = true
+
+	for len(s) > 0 {
+		if n := escapeLen(s); n > 0 {
+			w.word, s = append(w.word, wrapToken{s: s[:n], esc: true}), s[n:]
+
+			continue
+		}
+
+		var n = grapheme.Next(s)
+
+		if s[0] == ' ' || s[0] == '\t' {
+			w.commitWord()
+			w.spaces++
+		} else {
+			var gw = grapheme.Width(s[:n])
+
+			w.word, w.wordW = append(w.word, wrapToken{s: s[:n], width: gw}), w.wordW+gw
+		}
+
+		s = s[n:]
+	}
+
+	w.commitWord()
+}
+
+// write writes the token into the current line.
+func (w *wordWrapper) write(t wrapToken) {
+	w.line.WriteString(t.s)
+	w.lineW += t.width
+
+	if t.esc && isSGR(t.s) {
+		w.state.apply(t.s)
+	}
+}
+
+// commitWord moves the pending word into the current line (or the next one, if it does not fit).
+func (w *wordWrapper) commitWord() {
+	if len(w.word) == 0 {
+		return
+	}
+
+	if w.lineW > 0 && w.lineW+w.spaces+w.wordW > w.width {
+		w.breakLine()
+	} else if w.lineW > 0 || w.fresh {
+		w.line.WriteString(strings.Repeat(" ", w.spaces))
+		w.lineW += w.spaces
+	}
+
+	for _, t := range w.word {
+		if !t.esc && w.lineW > 0 && w.lineW+t.width > w.width { // too long word
+			w.breakLine()
+		}
+
+		w.write(t)
+	}
+
+	w.word, w.wordW, w.spaces, w.fresh = w.word[:0], 0, 0, false
+}
+
+// newLine commits the pending word, finishes the current line and starts a new one.
+func (w *wordWrapper) newLine() {
+	w.commitWord()
+	w.breakLine()
+}
+
+// breakLine finishes the current line and starts a new one (restoring the active styles).
+func (w *wordWrapper) breakLine() {
+	var active = w.state.sequence()
+
+	if active != "" {
+		w.line.WriteString("\x1b[0m")
+	}
+
+	w.lines = append(w.lines, w.line.String())
+	w.line.Reset()
+	w.lineW, w.spaces, w.fresh = 0, 0, false
+
+	w.line.WriteString(active)
+}
+
+// sgrState is a state of the SGR (Select Graphic Rendition) attributes - the result of applying a series of SGR
+// sequences.
+type sgrState struct {
+	attrs     [10]bool // 1 (bold) .. 9 (strike)
+	fg, bg, u string   // raw color parameters (e.g. "31", "38;5;1" or "48;2;1;2;3"), u is the underline color
+}
+
+// apply applies the SGR sequence to the state.
+func (st *sgrState) apply(seq string) {
+	var params = strings.Split(seq[2:len(seq)-1], ";")
+
+	for i := 0; i < len(params); i++ {
+		var code, err = strconv.Atoi(strings.SplitN(params[i], ":", 2)[0]) //nolint:mnd // sub-parameters are ignored
+		if err != nil && params[i] != "" {
+			continue
+		}
+
+		switch {
+		case code == 0:
+			*st = sgrState{}
+		case code >= 1 && code <= 9:
+			st.attrs[code] = true
+		case code == 21, code == 22: //nolint:mnd
+			st.attrs[1], st.attrs[2] = false, false
+		case code >= 23 && code <= 29 && code != 26: //nolint:mnd
+			st.attrs[code-20] = false
+
+			if code == 25 { //nolint:mnd
+				st.attrs[6] = false
+			}
+		case code >= 30 && code <= 37, code >= 90 && code <= 97:
+			st.fg = params[i]
+		case code >= 40 && code <= 47, code >= 100 && code <= 107:
+			st.bg = params[i]
+		case code == 39: //nolint:mnd
+			st.fg = ""
+		case code == 49: //nolint:mnd
+			st.bg = ""
+		case code == 59: //nolint:mnd
+			st.u = ""
+		case code == 38 || code == 48 || code == 58: // extended colors - "5;n" or "2;r;g;b"
+			var n = 2 //nolint:mnd
+
+			if i+1 < len(params) && params[i+1] == "2" {
+				n = 4 //nolint:mnd
+			}
+
+			var ext = strings.Join(params[i:min(i+n+1, len(params))], ";")
+
+			switch code {
+			case 38: //nolint:mnd
+				st.fg = ext
+			case 48: //nolint:mnd
+				st.bg = ext
+			default:
+				st.u = ext
+			}
+
+			i += n
+		}
+	}
+}
+
+// sequence returns the SGR sequence, that restores the state (an empty string for the default state).
+func (st *sgrState) sequence() string {
+	var params = make([]string, 0, 4) //nolint:mnd
+
+	for code, on := range st.attrs {
+		if on {
+			params = append(params, strconv.Itoa(code))
+		}
+	}
+
+	for _, color := range [...]string{st.fg, st.bg, st.u} {
+		if color != "" {
+			params = append(params, color)
+		}
+	}
+
+	if len(params) == 0 {
+		return ""
+	}
+
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
+
+// TerminalWidth returns the width (in columns) of the terminal, the writer is associated with. If the writer is not
+// a terminal, the value of the COLUMNS environment variable is used. Zero is returned when the width is unknown.
+func TerminalWidth(w io.Writer) int {
+	if f, isFile := w.(interface{ Fd() uintptr }); isFile {
+		if width, ok := termsize.Width(f.Fd()); ok {
+			return width
+		}
+	}
+
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+
+	return 0
+}
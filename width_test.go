@@ -0,0 +1,121 @@
+package colors_test
+
+import (
+	"strings"
+	"testing"
+
+	"gh.tarampamp.am/colors"
+)
+
+func TestStripANSI(t *testing.T) {
+	for give, want := range map[string]string{
+		"":                             "",
+		"foo":                          "foo",
+		"\x1b[1;31mfoo\x1b[39;22m bar": "foo bar",
+		"\x1b]0;title\afoo":            "foo",
+		"\x1b]8;;https://x\x1b\\link\x1b]8;;\x1b\\": "link",
+		"\x1b7foo\x1b8": "foo",
+		"\x1b(Bfoo":     "foo",
+		"foo\x1b[":      "foo",
+		"\x1b":          "",
+	} {
+		assertEqualValues(t, want, colors.StripANSI(give))
+	}
+}
+
+func TestVisibleWidth(t *testing.T) {
+	for give, want := range map[string]int{
+		"":                                     0,
+		"foo":                                  3,
+		"\x1b[1;31mfoo\x1b[39;22m":             3,
+		"日本":                                   4,
+		"e\u0301":                              1,
+		"\U0001F44D ok":                        5,
+		"foo\nfoobar\nx":                       6,
+		(colors.FgRed | colors.Bold).Wrap("x"): 1,
+	} {
+		assertEqualValues(t, want, colors.VisibleWidth(give))
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	for name, tt := range map[string]struct {
+		give     string
+		giveW    int
+		giveTail string
+		want     string
+	}{
+		"fits":          {"foo", 3, "…", "foo"},
+		"truncated":     {"foobar", 4, "…", "foo…"},
+		"no tail":       {"foobar", 4, "", "foob"},
+		"styled":        {"\x1b[31mfoobar\x1b[39m", 4, "…", "\x1b[31mfoo…\x1b[39m"},
+		"wide":          {"日本語", 5, "…", "日本…"},
+		"wide boundary": {"日本語", 4, "", "日本"},
+		"wide odd":      {"日本語", 3, "", "日"},
+		"tail too long": {"foobar", 1, "...", "f"},
+		"zero":          {"foobar", 0, "…", ""},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var got = colors.Truncate(tt.give, tt.giveW, tt.giveTail)
+
+			assertEqualValues(t, tt.want, got)
+			assertTrue(t, colors.VisibleWidth(got) <= tt.giveW)
+		})
+	}
+}
+
+func TestWordWrap(t *testing.T) {
+	for name, tt := range map[string]struct {
+		give  string
+		giveW int
+		want  []string
+	}{
+		"empty":          {"", 10, []string{""}},
+		"fits":           {"foo bar", 10, []string{"foo bar"}},
+		"wrapped":        {"foo bar baz", 7, []string{"foo bar", "baz"}},
+		"multiple":       {"the quick brown fox jumps", 10, []string{"the quick", "brown fox", "jumps"}},
+		"long word":      {"abcdefghij kl", 4, []string{"abcd", "efgh", "ij", "kl"}},
+		"line breaks":    {"foo\n\nbar baz", 3, []string{"foo", "", "bar", "baz"}},
+		"indentation":    {"  foo bar", 6, []string{"  foo", "bar"}},
+		"wide":           {"日本語 日本", 6, []string{"日本語", "日本"}},
+		"extra spaces":   {"foo    bar", 5, []string{"foo", "bar"}},
+		"zero width":     {"ab", 0, []string{"a", "b"}},
+		"style restored": {"\x1b[31mfoo bar\x1b[39m", 3, []string{"\x1b[31mfoo\x1b[0m", "\x1b[31mbar\x1b[39m"}},
+		"extended colors": {
+			"\x1b[1;38;5;196;48;2;1;2;3mfoo bar\x1b[0m", 3,
+			[]string{"\x1b[1;38;5;196;48;2;1;2;3mfoo\x1b[0m", "\x1b[1;38;5;196;48;2;1;2;3mbar\x1b[0m"},
+		},
+		"partially reset": {
+			"\x1b[1;3;31mfoo\x1b[22;39m bar", 3, []string{"\x1b[1;3;31mfoo\x1b[22;39m\x1b[0m", "\x1b[3mbar\x1b[0m"},
+		},
+		"style reset": {
+			"\x1b[1mfoo\x1b[0m bar", 3, []string{"\x1b[1mfoo\x1b[0m", "bar"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var got = colors.WordWrap(tt.give, tt.giveW)
+
+			assertEqualValues(t, tt.want, got)
+
+			for _, line := range got {
+				assertTrue(t, colors.VisibleWidth(line) <= max(tt.giveW, 1) || strings.Contains(line, "日"))
+			}
+		})
+	}
+}
+
+func TestTerminalWidth(t *testing.T) {
+	var buf strings.Builder
+
+	t.Setenv("COLUMNS", "")
+
+	assertEqualValues(t, 0, colors.TerminalWidth(&buf))
+
+	t.Setenv("COLUMNS", "100")
+
+	assertEqualValues(t, 100, colors.TerminalWidth(&buf))
+
+	t.Setenv("COLUMNS", "foo")
+
+	assertEqualValues(t, 0, colors.TerminalWidth(&buf))
+}